@@ -0,0 +1,91 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+
+	"github.com/skckadiyala/descheduler-operator/pkg/controller/descheduler"
+	"github.com/spf13/pflag"
+	batchv1beta1 "k8s.io/api/batch/v1beta1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/yaml"
+)
+
+// importPolicy prints the Descheduler CR equivalent to the policy.yaml and the CronJob of an upstream descheduler,
+// the settings the CR can't express are reported on errOut:
+//
+//	descheduler-operator import --policy policy.yaml --cronjob cronjob.yaml
+func importPolicy(args []string, out, errOut io.Writer) error {
+	flags := pflag.NewFlagSet("import", pflag.ContinueOnError)
+	policyFile := flags.String("policy", "", "policy.yaml of the upstream descheduler")
+	cronJobFile := flags.String("cronjob", "", "CronJob running the upstream descheduler")
+	name := flags.String("name", "", "Name of the Descheduler, the name of the CronJob by default")
+	namespace := flags.StringP("namespace", "n", "", "Namespace of the Descheduler, the namespace of the CronJob by default")
+	schedule := flags.String("schedule", "", "Schedule of the descheduler, overrides the schedule of the CronJob")
+	image := flags.String("image", "", "Image of the descheduler, overrides the image of the CronJob")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *policyFile == "" {
+		return fmt.Errorf("import needs the upstream policy, set it with --policy")
+	}
+	policy, err := ioutil.ReadFile(*policyFile)
+	if err != nil {
+		return err
+	}
+
+	source := descheduler.ImportSource{}
+	if *cronJobFile != "" {
+		content, err := ioutil.ReadFile(*cronJobFile)
+		if err != nil {
+			return err
+		}
+		cronJob := &batchv1beta1.CronJob{}
+		if err := yaml.Unmarshal(content, cronJob); err != nil {
+			return fmt.Errorf("error reading %s %v", *cronJobFile, err)
+		}
+		source = descheduler.ImportCronJob(cronJob)
+		if *name == "" {
+			*name = cronJob.Name
+		}
+		if *namespace == "" {
+			*namespace = cronJob.Namespace
+		}
+	}
+	// Arguments after -- are the command of the descheduler container when there is no CronJob
+	if dash := flags.ArgsLenAtDash(); dash >= 0 {
+		source.Args = flags.Args()[dash:]
+	}
+	if *schedule != "" {
+		source.Schedule = *schedule
+	}
+	if *image != "" {
+		source.Image = *image
+	}
+	if *name == "" {
+		*name = "descheduler"
+	}
+
+	d, unsupported, err := descheduler.ImportPolicy(*name, *namespace, policy, source)
+	for _, setting := range unsupported {
+		fmt.Fprintf(errOut, "warning: %s can't be expressed in a Descheduler, dropped\n", setting)
+	}
+	if err != nil {
+		return err
+	}
+	// Leave out the empty status and creation timestamp of the new object
+	obj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(d)
+	if err != nil {
+		return err
+	}
+	delete(obj, "status")
+	unstructured.RemoveNestedField(obj, "metadata", "creationTimestamp")
+	manifest, err := yaml.Marshal(obj)
+	if err != nil {
+		return err
+	}
+	_, err = out.Write(manifest)
+	return err
+}
@@ -12,6 +12,7 @@ import (
 
 	"github.com/skckadiyala/descheduler-operator/pkg/apis"
 	"github.com/skckadiyala/descheduler-operator/pkg/controller"
+	"github.com/skckadiyala/descheduler-operator/pkg/webhook"
 
 	"github.com/operator-framework/operator-sdk/pkg/k8sutil"
 	"github.com/operator-framework/operator-sdk/pkg/leader"
@@ -31,6 +32,20 @@ var (
 	metricsPort         int32 = 8383
 	operatorMetricsPort int32 = 8686
 )
+
+// Change below variables to serve webhooks on a different port or with certificates from another directory.
+var (
+	webhookPort    int32 = 9443
+	webhookCertDir       = "/tmp/k8s-webhook-server/serving-certs"
+)
+
+// Change below variables to provision the webhook certificate in another secret or for other webhooks.
+var (
+	webhookCertSecret        = "descheduler-operator-webhook-cert"
+	webhookServiceName       = "descheduler-operator-webhook"
+	webhookConfigurationName = "descheduler-operator"
+	deschedulerCRDName       = "deschedulers.descheduler.axway.com"
+)
 var log = logf.Log.WithName("cmd")
 
 func printVersion() {
@@ -40,6 +55,21 @@ func printVersion() {
 }
 
 func main() {
+	// render and import run offline, they don't start the manager
+	if len(os.Args) > 1 && (os.Args[1] == "render" || os.Args[1] == "import") {
+		var err error
+		if os.Args[1] == "render" {
+			err = render(os.Args[2:], os.Stdout)
+		} else {
+			err = importPolicy(os.Args[2:], os.Stdout, os.Stderr)
+		}
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	// Add the zap logger flag set to the CLI. The flag set must
 	// be added before calling pflag.Parse().
 	pflag.CommandLine.AddFlagSet(zap.FlagSet())
@@ -108,6 +138,24 @@ func main() {
 		os.Exit(1)
 	}
 
+	// Setup all Webhooks, their certificate is provisioned when the operator runs in a cluster
+	var certs *webhook.CertOptions
+	if operatorNamespace, err := k8sutil.GetOperatorNamespace(); err == nil {
+		certs = &webhook.CertOptions{
+			Namespace:                operatorNamespace,
+			SecretName:               webhookCertSecret,
+			ServiceName:              webhookServiceName,
+			WebhookConfigurationName: webhookConfigurationName,
+			CRDName:                  deschedulerCRDName,
+		}
+	} else {
+		log.Info("Not running in a cluster, serving the webhook certificate of", "certDir", webhookCertDir)
+	}
+	if err := webhook.AddToManager(mgr, webhookPort, webhookCertDir, certs); err != nil {
+		log.Error(err, "")
+		os.Exit(1)
+	}
+
 	log.Info("Starting the Cmd.")
 
 	// Start the Cmd
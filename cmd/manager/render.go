@@ -0,0 +1,98 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+
+	"github.com/skckadiyala/descheduler-operator/pkg/apis"
+	deschedulerv1alpha1 "github.com/skckadiyala/descheduler-operator/pkg/apis/descheduler/v1alpha1"
+	deschedulerv1beta1 "github.com/skckadiyala/descheduler-operator/pkg/apis/descheduler/v1beta1"
+	"github.com/skckadiyala/descheduler-operator/pkg/controller/descheduler"
+	"github.com/spf13/pflag"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/yaml"
+)
+
+// render prints the manifests the operator creates for the Descheduler CR of a file, without a cluster connection:
+//
+//	descheduler-operator render -f cr.yaml
+func render(args []string, out io.Writer) error {
+	flags := pflag.NewFlagSet("render", pflag.ContinueOnError)
+	file := flags.StringP("filename", "f", "", "Descheduler CR to render, - for stdin")
+	namespace := flags.StringP("namespace", "n", "default", "Namespace of the CR when it sets none")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *file == "" {
+		return fmt.Errorf("render needs a Descheduler CR, set it with -f")
+	}
+
+	var content []byte
+	var err error
+	if *file == "-" {
+		content, err = ioutil.ReadAll(os.Stdin)
+	} else {
+		content, err = ioutil.ReadFile(*file)
+	}
+	if err != nil {
+		return err
+	}
+	d, err := decodeDescheduler(content)
+	if err != nil {
+		return fmt.Errorf("error reading %s %v", *file, err)
+	}
+	if d.Namespace == "" {
+		d.Namespace = *namespace
+	}
+
+	scheme := runtime.NewScheme()
+	if err := apis.AddToScheme(scheme); err != nil {
+		return err
+	}
+	objects, err := descheduler.Render(d, scheme)
+	if err != nil {
+		return err
+	}
+	for _, object := range objects {
+		manifest, err := yaml.Marshal(object)
+		if err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintf(out, "---\n%s", manifest); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// decodeDescheduler reads a Descheduler CR of either version, v1alpha1 CRs are converted to v1beta1
+func decodeDescheduler(content []byte) (*deschedulerv1beta1.Descheduler, error) {
+	typeMeta := metav1.TypeMeta{}
+	if err := yaml.Unmarshal(content, &typeMeta); err != nil {
+		return nil, err
+	}
+	if typeMeta.Kind != "Descheduler" {
+		return nil, fmt.Errorf("expected a Descheduler, found kind %q", typeMeta.Kind)
+	}
+	d := &deschedulerv1beta1.Descheduler{}
+	switch typeMeta.APIVersion {
+	case deschedulerv1beta1.SchemeGroupVersion.String():
+		if err := yaml.UnmarshalStrict(content, d); err != nil {
+			return nil, err
+		}
+	case deschedulerv1alpha1.SchemeGroupVersion.String():
+		alpha := &deschedulerv1alpha1.Descheduler{}
+		if err := yaml.UnmarshalStrict(content, alpha); err != nil {
+			return nil, err
+		}
+		if err := alpha.ConvertTo(d); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unsupported apiVersion %q", typeMeta.APIVersion)
+	}
+	return d, nil
+}
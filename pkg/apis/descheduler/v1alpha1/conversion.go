@@ -0,0 +1,280 @@
+package v1alpha1
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/skckadiyala/descheduler-operator/pkg/apis/descheduler/v1beta1"
+)
+
+// Strategy names accepted by v1alpha1
+const (
+	StrategyDuplicates           = "duplicates"
+	StrategyInterPodAntiAffinity = "interpodantiaffinity"
+	StrategyLowNodeUtilization   = "lownodeutilization"
+	StrategyNodeAffinity         = "nodeaffinity"
+)
+
+// Params accepted by the lownodeutilization strategy in v1alpha1
+const (
+	ParamCPUThreshold          = "cputhreshold"
+	ParamMemoryThreshold       = "memorythreshold"
+	ParamPodsThreshold         = "podsthreshold"
+	ParamCPUTargetThreshold    = "cputargetthreshold"
+	ParamMemoryTargetThreshold = "memorytargetthreshold"
+	ParamPodsTargetThreshold   = "podstargetthreshold"
+	ParamNodes                 = "nodes"
+)
+
+// SpecAnnotation keeps the v1beta1 spec of a Descheduler converted to v1alpha1, so that the fields
+// v1alpha1 cannot express survive a round trip through this version.
+const SpecAnnotation = "descheduler.axway.com/v1beta1-spec"
+
+// StrategiesAnnotation keeps the v1alpha1 strategies of a Descheduler converted to v1beta1 when v1beta1 can't express
+// them, e.g. unknown strategies or params stored before the validating webhook, so they are converted back as stored.
+const StrategiesAnnotation = "descheduler.axway.com/v1alpha1-strategies"
+
+// ConvertTo converts this Descheduler to the v1beta1 version. Like the v1alpha1 renderer, it ignores unknown
+// strategies and params and treats non numeric values as unset: stored objects must always convert, the validating
+// webhook rejects them on create and update.
+func (src *Descheduler) ConvertTo(dst *v1beta1.Descheduler) error {
+	dst.ObjectMeta = src.ObjectMeta
+
+	strategies, lossless := convertStrategiesTo(src.Spec.Strategies)
+	dst.Spec.Strategies = strategies
+	dst.Spec.Schedule = src.Spec.Schedule
+	dst.Spec.Image = src.Spec.Image
+	dst.Spec.Flags = nil
+	for _, flag := range src.Spec.Flags {
+		dst.Spec.Flags = append(dst.Spec.Flags, v1beta1.Param{Name: flag.Name, Value: flag.Value})
+	}
+	restoreSpec(dst)
+	if !lossless {
+		if strategiesJSON, err := json.Marshal(src.Spec.Strategies); err == nil {
+			dst.Annotations = withAnnotation(dst.Annotations, StrategiesAnnotation, string(strategiesJSON))
+		}
+	}
+
+	dst.Status.Phase = src.Status.Phase
+	return nil
+}
+
+// withAnnotation returns a copy of annotations with key set to value, or without key when value is empty
+func withAnnotation(annotations map[string]string, key, value string) map[string]string {
+	copied := make(map[string]string, len(annotations)+1)
+	for k, v := range annotations {
+		copied[k] = v
+	}
+	if len(value) == 0 {
+		delete(copied, key)
+	} else {
+		copied[key] = value
+	}
+	return copied
+}
+
+// restoreSpec restores the fields v1alpha1 cannot express from the SpecAnnotation of dst, an annotation that
+// doesn't decode is dropped
+func restoreSpec(dst *v1beta1.Descheduler) {
+	specJSON, ok := dst.Annotations[SpecAnnotation]
+	if !ok {
+		return
+	}
+	dst.Annotations = withAnnotation(dst.Annotations, SpecAnnotation, "")
+
+	spec := v1beta1.DeschedulerSpec{}
+	if err := json.Unmarshal([]byte(specJSON), &spec); err != nil {
+		return
+	}
+	dst.Spec.LogVerbosity = spec.LogVerbosity
+	dst.Spec.Mode = spec.Mode
+	dst.Spec.RunRetention = spec.RunRetention
+	dst.Spec.RunHistoryLimit = spec.RunHistoryLimit
+	dst.Spec.ImagePullFailureThreshold = spec.ImagePullFailureThreshold
+	dst.Spec.Suspend = spec.Suspend
+	dst.Spec.TerminateActiveRuns = spec.TerminateActiveRuns
+	dst.Spec.DryRun = spec.DryRun
+	dst.Spec.AdoptExisting = spec.AdoptExisting
+	dst.Spec.PriorityThreshold = spec.PriorityThreshold
+	dst.Spec.EvictorOptions = spec.EvictorOptions
+	dst.Spec.PolicyVersion = spec.PolicyVersion
+	dst.Spec.Profiles = spec.Profiles
+	dst.Spec.Policy = spec.Policy
+	// v1alpha1 only enables these strategies, their parameters are restored when it keeps them enabled
+	if dst.Spec.Strategies.RemoveDuplicates != nil && spec.Strategies.RemoveDuplicates != nil {
+		dst.Spec.Strategies.RemoveDuplicates = spec.Strategies.RemoveDuplicates
+	}
+	if dst.Spec.Strategies.RemovePodsViolatingInterPodAntiAffinity != nil && spec.Strategies.RemovePodsViolatingInterPodAntiAffinity != nil {
+		dst.Spec.Strategies.RemovePodsViolatingInterPodAntiAffinity = spec.Strategies.RemovePodsViolatingInterPodAntiAffinity
+	}
+	if dst.Spec.Strategies.RemovePodsViolatingNodeAffinity != nil && spec.Strategies.RemovePodsViolatingNodeAffinity != nil {
+		dst.Spec.Strategies.RemovePodsViolatingNodeAffinity = spec.Strategies.RemovePodsViolatingNodeAffinity
+	}
+	if dst.Spec.Strategies.LowNodeUtilization != nil && spec.Strategies.LowNodeUtilization != nil {
+		dst.Spec.Strategies.LowNodeUtilization.PriorityThreshold = spec.Strategies.LowNodeUtilization.PriorityThreshold
+	}
+	// The strategies v1alpha1 doesn't know can't be changed through it
+	dst.Spec.Strategies.RemovePodsViolatingNodeTaints = spec.Strategies.RemovePodsViolatingNodeTaints
+	dst.Spec.Strategies.RemovePodsHavingTooManyRestarts = spec.Strategies.RemovePodsHavingTooManyRestarts
+	dst.Spec.Strategies.PodLifeTime = spec.Strategies.PodLifeTime
+	dst.Spec.Strategies.RemovePodsViolatingTopologySpreadConstraint = spec.Strategies.RemovePodsViolatingTopologySpreadConstraint
+	dst.Spec.Strategies.HighNodeUtilization = spec.Strategies.HighNodeUtilization
+	dst.Spec.Strategies.RemoveFailedPods = spec.Strategies.RemoveFailedPods
+}
+
+// ConvertFrom converts the v1beta1 version of a Descheduler to this version.
+func (dst *Descheduler) ConvertFrom(src *v1beta1.Descheduler) error {
+	dst.ObjectMeta = src.ObjectMeta
+	specJSON, err := json.Marshal(src.Spec)
+	if err != nil {
+		return err
+	}
+	dst.Annotations = withAnnotation(src.Annotations, SpecAnnotation, string(specJSON))
+
+	dst.Spec.Strategies = convertStrategiesFrom(src.Spec.Strategies)
+	if strategiesJSON, ok := dst.Annotations[StrategiesAnnotation]; ok {
+		dst.Annotations = withAnnotation(dst.Annotations, StrategiesAnnotation, "")
+		// The stored strategies are kept unless the strategies were changed through v1beta1 since
+		var stored []Strategy
+		if err := json.Unmarshal([]byte(strategiesJSON), &stored); err == nil {
+			if converted, _ := convertStrategiesTo(stored); reflect.DeepEqual(convertStrategiesFrom(converted), dst.Spec.Strategies) {
+				dst.Spec.Strategies = stored
+			}
+		}
+	}
+	dst.Spec.Schedule = src.Spec.Schedule
+	dst.Spec.Image = src.Spec.Image
+	// flags is a required field in v1alpha1
+	dst.Spec.Flags = make([]Param, 0, len(src.Spec.Flags))
+	for _, flag := range src.Spec.Flags {
+		dst.Spec.Flags = append(dst.Spec.Flags, Param{Name: flag.Name, Value: flag.Value})
+	}
+
+	dst.Status.Phase = src.Status.Phase
+	return nil
+}
+
+// convertStrategiesTo converts the strategies the way the v1alpha1 renderer read them, lossless is false when some
+// strategies or params aren't converted
+func convertStrategiesTo(strategies []Strategy) (converted v1beta1.DeschedulerStrategies, lossless bool) {
+	lossless = ValidateStrategies(strategies) == nil
+	for _, strategy := range strategies {
+		switch strings.ToLower(strategy.Name) {
+		case StrategyDuplicates:
+			converted.RemoveDuplicates = &v1beta1.RemoveDuplicatesStrategy{}
+		case StrategyInterPodAntiAffinity:
+			converted.RemovePodsViolatingInterPodAntiAffinity = &v1beta1.RemovePodsViolatingInterPodAntiAffinityStrategy{}
+		case StrategyLowNodeUtilization:
+			// The params of a strategy listed again add up
+			if converted.LowNodeUtilization == nil {
+				converted.LowNodeUtilization = &v1beta1.LowNodeUtilizationStrategy{}
+			}
+			convertLowNodeUtilizationTo(converted.LowNodeUtilization, strategy.Params)
+		case StrategyNodeAffinity:
+			converted.RemovePodsViolatingNodeAffinity = &v1beta1.RemovePodsViolatingNodeAffinityStrategy{}
+		}
+	}
+	return converted, lossless
+}
+
+// lowNodeUtilizationParam returns the field of the lownodeutilization param, nil for an unknown param
+func lowNodeUtilizationParam(lowNodeUtilization *v1beta1.LowNodeUtilizationStrategy, name string) *int32 {
+	switch name {
+	case ParamCPUThreshold:
+		return &lowNodeUtilization.Thresholds.CPU
+	case ParamMemoryThreshold:
+		return &lowNodeUtilization.Thresholds.Memory
+	case ParamPodsThreshold:
+		return &lowNodeUtilization.Thresholds.Pods
+	case ParamCPUTargetThreshold:
+		return &lowNodeUtilization.TargetThresholds.CPU
+	case ParamMemoryTargetThreshold:
+		return &lowNodeUtilization.TargetThresholds.Memory
+	case ParamPodsTargetThreshold:
+		return &lowNodeUtilization.TargetThresholds.Pods
+	case ParamNodes:
+		return &lowNodeUtilization.NumberOfNodes
+	}
+	return nil
+}
+
+// convertLowNodeUtilizationTo sets the known params, a non numeric value unsets the param
+func convertLowNodeUtilizationTo(lowNodeUtilization *v1beta1.LowNodeUtilizationStrategy, params []Param) {
+	for _, param := range params {
+		if field := lowNodeUtilizationParam(lowNodeUtilization, param.Name); field != nil {
+			value, _ := strconv.ParseInt(param.Value, 10, 32)
+			*field = int32(value)
+		}
+	}
+}
+
+// ValidateStrategies reports the first unknown or duplicate strategy, param of a strategy taking none, unknown
+// lownodeutilization param or non numeric value, conversion drops them
+func ValidateStrategies(strategies []Strategy) error {
+	seen := make(map[string]bool)
+	for _, strategy := range strategies {
+		name := strings.ToLower(strategy.Name)
+		if seen[name] {
+			return fmt.Errorf("strategy %q is listed more than once", strategy.Name)
+		}
+		seen[name] = true
+
+		switch name {
+		case StrategyDuplicates, StrategyInterPodAntiAffinity, StrategyNodeAffinity:
+			if len(strategy.Params) > 0 {
+				return fmt.Errorf("strategy %q does not accept params but found %v", strategy.Name, strategy.Params[0].Name)
+			}
+		case StrategyLowNodeUtilization:
+			for _, param := range strategy.Params {
+				if lowNodeUtilizationParam(&v1beta1.LowNodeUtilizationStrategy{}, param.Name) == nil {
+					return fmt.Errorf("unknown param %q for strategy %q, expected one of %v", param.Name, StrategyLowNodeUtilization,
+						strings.Join([]string{ParamCPUThreshold, ParamMemoryThreshold, ParamPodsThreshold, ParamCPUTargetThreshold,
+							ParamMemoryTargetThreshold, ParamPodsTargetThreshold, ParamNodes}, ","))
+				}
+				if _, err := strconv.ParseInt(param.Value, 10, 32); err != nil {
+					return fmt.Errorf("param %q of strategy %q must be an integer but found %q", param.Name, StrategyLowNodeUtilization, param.Value)
+				}
+			}
+		default:
+			return fmt.Errorf("unknown strategy %q, expected one of %v", strategy.Name,
+				strings.Join([]string{StrategyDuplicates, StrategyInterPodAntiAffinity, StrategyLowNodeUtilization, StrategyNodeAffinity}, ","))
+		}
+	}
+	return nil
+}
+
+func convertStrategiesFrom(strategies v1beta1.DeschedulerStrategies) []Strategy {
+	converted := make([]Strategy, 0)
+	if strategies.RemoveDuplicates != nil {
+		converted = append(converted, Strategy{Name: StrategyDuplicates, Params: []Param{}})
+	}
+	if strategies.RemovePodsViolatingInterPodAntiAffinity != nil {
+		converted = append(converted, Strategy{Name: StrategyInterPodAntiAffinity, Params: []Param{}})
+	}
+	if lowNodeUtilization := strategies.LowNodeUtilization; lowNodeUtilization != nil {
+		params := make([]Param, 0)
+		params = appendIntParam(params, ParamCPUThreshold, lowNodeUtilization.Thresholds.CPU)
+		params = appendIntParam(params, ParamMemoryThreshold, lowNodeUtilization.Thresholds.Memory)
+		params = appendIntParam(params, ParamPodsThreshold, lowNodeUtilization.Thresholds.Pods)
+		params = appendIntParam(params, ParamCPUTargetThreshold, lowNodeUtilization.TargetThresholds.CPU)
+		params = appendIntParam(params, ParamMemoryTargetThreshold, lowNodeUtilization.TargetThresholds.Memory)
+		params = appendIntParam(params, ParamPodsTargetThreshold, lowNodeUtilization.TargetThresholds.Pods)
+		params = appendIntParam(params, ParamNodes, lowNodeUtilization.NumberOfNodes)
+		converted = append(converted, Strategy{Name: StrategyLowNodeUtilization, Params: params})
+	}
+	if strategies.RemovePodsViolatingNodeAffinity != nil {
+		converted = append(converted, Strategy{Name: StrategyNodeAffinity, Params: []Param{}})
+	}
+	return converted
+}
+
+// appendIntParam appends name to params unless value is unset
+func appendIntParam(params []Param, name string, value int32) []Param {
+	if value == 0 {
+		return params
+	}
+	return append(params, Param{Name: name, Value: strconv.Itoa(int(value))})
+}
@@ -0,0 +1,239 @@
+package v1alpha1
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/skckadiyala/descheduler-operator/pkg/apis/descheduler/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func int32Ptr(value int32) *int32 {
+	return &value
+}
+
+// fullSpec sets every field of the v1beta1 spec, so a field added without being restored fails the round trip
+func fullSpec() v1beta1.DeschedulerSpec {
+	threshold := v1beta1.PriorityThreshold{ThresholdPriority: int32Ptr(1000)}
+	namespaces := &v1beta1.Namespaces{Exclude: []string{"kube-system"}}
+	strategies := v1beta1.DeschedulerStrategies{
+		RemoveDuplicates: &v1beta1.RemoveDuplicatesStrategy{Namespaces: namespaces, PriorityThreshold: threshold},
+		LowNodeUtilization: &v1beta1.LowNodeUtilizationStrategy{
+			Thresholds:        v1beta1.ResourceThresholds{CPU: 20, Memory: 20, Pods: 20},
+			TargetThresholds:  v1beta1.ResourceThresholds{CPU: 50, Memory: 50, Pods: 50},
+			NumberOfNodes:     2,
+			PriorityThreshold: v1beta1.PriorityThreshold{ThresholdPriorityClassName: "critical"},
+		},
+		RemovePodsViolatingInterPodAntiAffinity: &v1beta1.RemovePodsViolatingInterPodAntiAffinityStrategy{
+			Namespaces: namespaces, PriorityThreshold: threshold,
+		},
+		RemovePodsViolatingNodeAffinity: &v1beta1.RemovePodsViolatingNodeAffinityStrategy{
+			NodeAffinityType: []string{"requiredDuringSchedulingIgnoredDuringExecution"}, Namespaces: namespaces,
+		},
+		RemovePodsViolatingNodeTaints: &v1beta1.RemovePodsViolatingNodeTaintsStrategy{
+			ExcludedTaints: []string{"dedicated=infra"}, IncludePreferNoSchedule: true,
+		},
+		RemovePodsHavingTooManyRestarts: &v1beta1.RemovePodsHavingTooManyRestartsStrategy{
+			PodRestartThreshold: 100, IncludingInitContainers: true,
+		},
+		PodLifeTime: &v1beta1.PodLifeTimeStrategy{
+			MaxPodLifeTimeSeconds: 86400, PodStatusPhases: []corev1.PodPhase{corev1.PodPending},
+		},
+		RemovePodsViolatingTopologySpreadConstraint: &v1beta1.RemovePodsViolatingTopologySpreadConstraintStrategy{
+			IncludeSoftConstraints: true,
+		},
+		HighNodeUtilization: &v1beta1.HighNodeUtilizationStrategy{Thresholds: v1beta1.ResourceThresholds{CPU: 10}},
+		RemoveFailedPods: &v1beta1.RemoveFailedPodsStrategy{
+			Reasons: []string{"OutOfcpu"}, ExcludeOwnerKinds: []string{"Job"}, MinPodLifetimeSeconds: 3600,
+		},
+	}
+	return v1beta1.DeschedulerSpec{
+		Strategies:                strategies,
+		Mode:                      v1beta1.ModeDeployment,
+		Schedule:                  "*/10 * * * *",
+		Flags:                     []v1beta1.Param{{Name: "descheduling-interval", Value: "5m"}},
+		Image:                     "registry.k8s.io/descheduler/descheduler:v0.29.0",
+		LogVerbosity:              int32Ptr(3),
+		RunRetention:              &v1beta1.RunRetention{MaxCount: int32Ptr(5), MaxAge: &metav1.Duration{Duration: time.Hour}},
+		RunHistoryLimit:           int32Ptr(20),
+		ImagePullFailureThreshold: int32Ptr(2),
+		Suspend:                   true,
+		TerminateActiveRuns:       true,
+		DryRun:                    true,
+		AdoptExisting:             v1beta1.AdoptPolicyAdopt,
+		// Conversion doesn't validate, both fields of the threshold are set to cover them
+		PriorityThreshold: v1beta1.PriorityThreshold{ThresholdPriority: int32Ptr(1000), ThresholdPriorityClassName: "critical"},
+		EvictorOptions: v1beta1.EvictorOptions{
+			EvictLocalStoragePods:          true,
+			EvictSystemCriticalPods:        true,
+			IgnorePvcPods:                  true,
+			MaxNoOfPodsToEvictPerNode:      int32Ptr(10),
+			MaxNoOfPodsToEvictPerNamespace: int32Ptr(5),
+		},
+		PolicyVersion: v1beta1.PolicyVersionV1alpha2,
+		Profiles: []v1beta1.DeschedulerProfile{{
+			Name:              "cleanup",
+			Strategies:        v1beta1.DeschedulerStrategies{PodLifeTime: &v1beta1.PodLifeTimeStrategy{MaxPodLifeTimeSeconds: 3600}},
+			PriorityThreshold: threshold,
+		}},
+		Policy: &v1beta1.PolicySource{
+			Raw:          &runtime.RawExtension{Raw: []byte(`{"apiVersion":"descheduler/v1alpha2","kind":"DeschedulerPolicy"}`)},
+			ConfigMapRef: &v1beta1.PolicyConfigMapReference{Name: "policy", Key: "policy.yaml"},
+		},
+	}
+}
+
+// unsetFields returns the fields of a struct left to their zero value, the fields of its inline structs included
+func unsetFields(value reflect.Value) []string {
+	var unset []string
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Type().Field(i)
+		if field.Anonymous {
+			unset = append(unset, unsetFields(value.Field(i))...)
+		} else if reflect.DeepEqual(value.Field(i).Interface(), reflect.Zero(field.Type).Interface()) {
+			unset = append(unset, field.Name)
+		}
+	}
+	return unset
+}
+
+func TestFullSpecSetsEveryField(t *testing.T) {
+	spec := fullSpec()
+	for _, field := range unsetFields(reflect.ValueOf(spec)) {
+		t.Errorf("spec.%s is not set by fullSpec, set it so the round trip covers it", field)
+	}
+	for _, field := range unsetFields(reflect.ValueOf(spec.Strategies)) {
+		t.Errorf("spec.strategies.%s is not set by fullSpec, set it so the round trip covers it", field)
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	original := &v1beta1.Descheduler{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "example-descheduler",
+			Namespace:   "kube-system",
+			Annotations: map[string]string{"team": "platform"},
+		},
+		Spec:   fullSpec(),
+		Status: v1beta1.DeschedulerStatus{Phase: "Updated"},
+	}
+
+	alpha := &Descheduler{}
+	if err := alpha.ConvertFrom(original.DeepCopy()); err != nil {
+		t.Fatalf("ConvertFrom: %v", err)
+	}
+	if _, ok := alpha.Annotations[SpecAnnotation]; !ok {
+		t.Fatalf("ConvertFrom didn't set the %s annotation", SpecAnnotation)
+	}
+	if len(alpha.Spec.Strategies) != 4 {
+		t.Errorf("expected the 4 strategies known to v1alpha1, got %v", alpha.Spec.Strategies)
+	}
+
+	beta := &v1beta1.Descheduler{}
+	if err := alpha.ConvertTo(beta); err != nil {
+		t.Fatalf("ConvertTo: %v", err)
+	}
+	if !reflect.DeepEqual(beta.Annotations, original.Annotations) {
+		t.Errorf("annotations: expected %v, got %v", original.Annotations, beta.Annotations)
+	}
+	if !reflect.DeepEqual(beta.Spec, original.Spec) {
+		expected, _ := json.Marshal(original.Spec)
+		got, _ := json.Marshal(beta.Spec)
+		t.Errorf("spec lost in the round trip\nexpected %s\ngot      %s", expected, got)
+	}
+	if beta.Status.Phase != original.Status.Phase {
+		t.Errorf("phase: expected %s, got %s", original.Status.Phase, beta.Status.Phase)
+	}
+}
+
+func TestConvertToDisablesStrategiesDroppedInV1alpha1(t *testing.T) {
+	original := &v1beta1.Descheduler{Spec: fullSpec()}
+	alpha := &Descheduler{}
+	if err := alpha.ConvertFrom(original); err != nil {
+		t.Fatalf("ConvertFrom: %v", err)
+	}
+	alpha.Spec.Strategies = []Strategy{{Name: StrategyDuplicates}}
+
+	beta := &v1beta1.Descheduler{}
+	if err := alpha.ConvertTo(beta); err != nil {
+		t.Fatalf("ConvertTo: %v", err)
+	}
+	if beta.Spec.Strategies.LowNodeUtilization != nil || beta.Spec.Strategies.RemovePodsViolatingNodeAffinity != nil {
+		t.Errorf("strategies removed through v1alpha1 were restored: %+v", beta.Spec.Strategies)
+	}
+	if !reflect.DeepEqual(beta.Spec.Strategies.RemoveDuplicates, original.Spec.Strategies.RemoveDuplicates) {
+		t.Errorf("params of removeDuplicates weren't restored: %+v", beta.Spec.Strategies.RemoveDuplicates)
+	}
+}
+
+func TestConvertToKeepsUnconvertibleStrategies(t *testing.T) {
+	stored := []Strategy{
+		{Name: "duplicates", Params: []Param{{Name: "namespace", Value: "default"}}},
+		{Name: "unknown", Params: []Param{}},
+		{Name: "lownodeutilization", Params: []Param{{Name: ParamCPUThreshold, Value: "20"}, {Name: "cputhreshhold", Value: "30"}}},
+		{Name: "lownodeutilization", Params: []Param{{Name: ParamCPUTargetThreshold, Value: "fifty"}, {Name: ParamNodes, Value: "3"}}},
+	}
+	alpha := &Descheduler{
+		ObjectMeta: metav1.ObjectMeta{Name: "example-descheduler", Annotations: map[string]string{"team": "platform"}},
+		Spec:       DeschedulerSpec{Strategies: stored, Flags: []Param{}},
+	}
+
+	beta := &v1beta1.Descheduler{}
+	if err := alpha.DeepCopy().ConvertTo(beta); err != nil {
+		t.Fatalf("ConvertTo: %v", err)
+	}
+	expected := v1beta1.DeschedulerStrategies{
+		RemoveDuplicates:   &v1beta1.RemoveDuplicatesStrategy{},
+		LowNodeUtilization: &v1beta1.LowNodeUtilizationStrategy{Thresholds: v1beta1.ResourceThresholds{CPU: 20}, NumberOfNodes: 3},
+	}
+	if !reflect.DeepEqual(beta.Spec.Strategies, expected) {
+		t.Errorf("expected the strategies the v1alpha1 renderer read %+v, got %+v", expected, beta.Spec.Strategies)
+	}
+	if _, ok := beta.Annotations[StrategiesAnnotation]; !ok {
+		t.Fatalf("ConvertTo didn't set the %s annotation", StrategiesAnnotation)
+	}
+
+	roundTrip := &Descheduler{}
+	if err := roundTrip.ConvertFrom(beta); err != nil {
+		t.Fatalf("ConvertFrom: %v", err)
+	}
+	if !reflect.DeepEqual(roundTrip.Spec.Strategies, stored) {
+		t.Errorf("strategies lost in the round trip\nexpected %+v\ngot      %+v", stored, roundTrip.Spec.Strategies)
+	}
+	if _, ok := roundTrip.Annotations[StrategiesAnnotation]; ok {
+		t.Errorf("the %s annotation leaked into v1alpha1", StrategiesAnnotation)
+	}
+
+	// Strategies changed through v1beta1 replace the stored ones
+	beta.Spec.Strategies.RemoveDuplicates = nil
+	changed := &Descheduler{}
+	if err := changed.ConvertFrom(beta); err != nil {
+		t.Fatalf("ConvertFrom: %v", err)
+	}
+	if len(changed.Spec.Strategies) != 1 || changed.Spec.Strategies[0].Name != StrategyLowNodeUtilization {
+		t.Errorf("expected only the lownodeutilization strategy of v1beta1, got %+v", changed.Spec.Strategies)
+	}
+}
+
+func TestValidateStrategies(t *testing.T) {
+	tests := map[string][]Strategy{
+		"unknown strategy":     {{Name: "unknown"}},
+		"duplicate strategy":   {{Name: StrategyDuplicates}, {Name: "Duplicates"}},
+		"params of duplicates": {{Name: StrategyDuplicates, Params: []Param{{Name: "namespace", Value: "default"}}}},
+		"unknown param":        {{Name: StrategyLowNodeUtilization, Params: []Param{{Name: "cputhreshhold", Value: "20"}}}},
+		"non numeric value":    {{Name: StrategyLowNodeUtilization, Params: []Param{{Name: ParamCPUThreshold, Value: "twenty"}}}},
+	}
+	for name, strategies := range tests {
+		if err := ValidateStrategies(strategies); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+	valid := []Strategy{{Name: StrategyDuplicates}, {Name: StrategyLowNodeUtilization, Params: []Param{{Name: ParamCPUThreshold, Value: "20"}}}}
+	if err := ValidateStrategies(valid); err != nil {
+		t.Errorf("expected valid strategies, got %v", err)
+	}
+}
@@ -0,0 +1,99 @@
+package v1beta1
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Defaults applied to the unset fields of a Descheduler. They are persisted by the defaulting webhook,
+// so changing them in a new operator release does not change existing Deschedulers.
+const (
+	// DefaultImage is a build of descheduler v0.9.0, its tag is the upstream release the image compatibility
+	// check reads
+	DefaultImage              = "skckadiyala/descheduler:v0.9.0"
+	DefaultSchedule           = "*/30 * * * *"
+	DefaultLogVerbosity int32 = 5
+	// DefaultRunRetentionMaxCount is the number of DeschedulerRun records kept
+	DefaultRunRetentionMaxCount int32 = 100
+	// DefaultRunRetentionMaxAge is how long DeschedulerRun records are kept
+	DefaultRunRetentionMaxAge = 30 * 24 * time.Hour
+	// DefaultRunHistoryLimit is the number of descheduler runs kept in the status
+	DefaultRunHistoryLimit int32 = 10
+	// DefaultImagePullFailureThreshold is the number of consecutive runs failing to pull the image before
+	// the operator falls back to DefaultImage
+	DefaultImagePullFailureThreshold int32 = 3
+	// DefaultPolicyConfigMapKey is the key of the policy in the ConfigMap of spec.policy.configMapRef
+	DefaultPolicyConfigMapKey = "policy.yaml"
+)
+
+var (
+	// DefaultThresholds are used by LowNodeUtilization when no threshold is set
+	DefaultThresholds = ResourceThresholds{CPU: 20, Memory: 20, Pods: 20}
+	// DefaultTargetThresholds are used by LowNodeUtilization when no target threshold is set
+	DefaultTargetThresholds = ResourceThresholds{CPU: 50, Memory: 50, Pods: 50}
+)
+
+// Default fills the unset fields of the Descheduler with their default value
+func (d *Descheduler) Default() {
+	if len(d.Spec.Image) == 0 {
+		d.Spec.Image = DefaultImage
+	}
+	if len(d.Spec.Mode) == 0 {
+		d.Spec.Mode = ModeCronJob
+	}
+	if len(d.Spec.AdoptExisting) == 0 {
+		d.Spec.AdoptExisting = AdoptPolicyRefuse
+	}
+	if len(d.Spec.Schedule) == 0 {
+		d.Spec.Schedule = DefaultSchedule
+	}
+	if d.Spec.LogVerbosity == nil {
+		logVerbosity := DefaultLogVerbosity
+		d.Spec.LogVerbosity = &logVerbosity
+	}
+	if d.Spec.ImagePullFailureThreshold == nil {
+		threshold := DefaultImagePullFailureThreshold
+		d.Spec.ImagePullFailureThreshold = &threshold
+	}
+	if d.Spec.RunHistoryLimit == nil {
+		limit := DefaultRunHistoryLimit
+		d.Spec.RunHistoryLimit = &limit
+	}
+	if d.Spec.RunRetention == nil {
+		d.Spec.RunRetention = &RunRetention{}
+	}
+	if d.Spec.RunRetention.MaxCount == nil {
+		maxCount := DefaultRunRetentionMaxCount
+		d.Spec.RunRetention.MaxCount = &maxCount
+	}
+	if d.Spec.RunRetention.MaxAge == nil {
+		d.Spec.RunRetention.MaxAge = &metav1.Duration{Duration: DefaultRunRetentionMaxAge}
+	}
+	if policy := d.Spec.Policy; policy != nil && policy.ConfigMapRef != nil && len(policy.ConfigMapRef.Key) == 0 {
+		policy.ConfigMapRef.Key = DefaultPolicyConfigMapKey
+	}
+
+	defaultStrategies(&d.Spec.Strategies)
+	for i := range d.Spec.Profiles {
+		defaultStrategies(&d.Spec.Profiles[i].Strategies)
+	}
+}
+
+// defaultStrategies fills the unset params of the enabled strategies
+func defaultStrategies(strategies *DeschedulerStrategies) {
+	if lowNodeUtilization := strategies.LowNodeUtilization; lowNodeUtilization != nil {
+		// Only default thresholds left entirely unset, a partial threshold is intentional
+		if lowNodeUtilization.Thresholds == (ResourceThresholds{}) {
+			lowNodeUtilization.Thresholds = DefaultThresholds
+		}
+		if lowNodeUtilization.TargetThresholds == (ResourceThresholds{}) {
+			lowNodeUtilization.TargetThresholds = DefaultTargetThresholds
+		}
+	}
+	if nodeAffinity := strategies.RemovePodsViolatingNodeAffinity; nodeAffinity != nil {
+		if len(nodeAffinity.NodeAffinityType) == 0 {
+			nodeAffinity.NodeAffinityType = []string{NodeAffinityTypeRequired}
+		}
+	}
+}
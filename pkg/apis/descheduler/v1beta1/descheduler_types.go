@@ -0,0 +1,567 @@
+package v1beta1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
+
+// DeschedulerSpec defines the desired state of Descheduler
+// +k8s:openapi-gen=true
+type DeschedulerSpec struct {
+	// Strategies that should be enabled in descheduler, a strategy left unset is disabled
+	Strategies DeschedulerStrategies `json:"strategies"`
+	// Mode is how descheduler runs, CronJob by default
+	// +kubebuilder:validation:Enum=CronJob,Deployment,Job
+	Mode DeschedulerMode `json:"mode,omitempty"`
+	// Schedule on which cronjob should run, only used in CronJob mode
+	Schedule string `json:"schedule,omitempty"`
+	// Flags for descheduler
+	Flags []Param `json:"flags,omitempty"`
+	// Image of the descheduler being managed, this includes the version
+	Image string `json:"image,omitempty"`
+	// LogVerbosity is the klog verbosity descheduler runs with, evictions are logged from 5 on
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=10
+	LogVerbosity *int32 `json:"logVerbosity,omitempty"`
+	// RunRetention bounds the DeschedulerRun records kept for the descheduler runs
+	RunRetention *RunRetention `json:"runRetention,omitempty"`
+	// RunHistoryLimit is the number of descheduler runs kept in status.runs
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	RunHistoryLimit *int32 `json:"runHistoryLimit,omitempty"`
+	// ImagePullFailureThreshold is the number of consecutive runs failing to pull the image before the operator
+	// runs the default image instead, until the image is changed. 0 disables the fallback.
+	// +kubebuilder:validation:Minimum=0
+	ImagePullFailureThreshold *int32 `json:"imagePullFailureThreshold,omitempty"`
+	// Suspend stops the CronJob from starting new runs and manual runs from being triggered
+	Suspend bool `json:"suspend,omitempty"`
+	// TerminateActiveRuns deletes the runs still active when the descheduler is suspended
+	TerminateActiveRuns bool `json:"terminateActiveRuns,omitempty"`
+	// DryRun runs descheduler with --dry-run, no pod is evicted and the pods it would evict are reported in
+	// status.dryRunReport and in the <name>-dry-run ConfigMap
+	DryRun bool `json:"dryRun,omitempty"`
+	// AdoptExisting is what the operator does with a ConfigMap, CronJob or Deployment named after the descheduler
+	// that it didn't create, Refuse by default
+	// +kubebuilder:validation:Enum=Adopt,Refuse
+	AdoptExisting AdoptPolicy `json:"adoptExisting,omitempty"`
+	// PriorityThreshold keeps the pods of the threshold priority or above from being evicted by any strategy
+	PriorityThreshold `json:",inline"`
+	// EvictorOptions select the pods descheduler may evict and bound the evictions of a run
+	EvictorOptions `json:",inline"`
+	// PolicyVersion is the API version of the policy rendered for descheduler. When unset it is v1alpha1, or
+	// v1alpha2 when the descheduler release of the image no longer reads v1alpha1. A v1alpha2 policy runs the
+	// strategies as the plugins of a profile named default unless profiles are set.
+	// +kubebuilder:validation:Enum=v1alpha1,v1alpha2
+	PolicyVersion PolicyVersion `json:"policyVersion,omitempty"`
+	// Profiles are the named sets of strategies of a v1alpha2 policy, they replace strategies
+	Profiles []DeschedulerProfile `json:"profiles,omitempty"`
+	// Policy is a complete descheduler policy used as is instead of strategies and profiles
+	Policy *PolicySource `json:"policy,omitempty"`
+}
+
+// PolicySource is a descheduler policy maintained outside of the typed strategies, exactly one of its fields is set
+// +k8s:openapi-gen=true
+type PolicySource struct {
+	// Raw is an embedded DeschedulerPolicy, descheduler/v1alpha1 or descheduler/v1alpha2
+	// +kubebuilder:pruning:PreserveUnknownFields
+	Raw *runtime.RawExtension `json:"raw,omitempty"`
+	// ConfigMapRef is a ConfigMap of the namespace of the descheduler holding the policy, the operator rolls its
+	// changes out
+	ConfigMapRef *PolicyConfigMapReference `json:"configMapRef,omitempty"`
+}
+
+// PolicyConfigMapReference refers to the policy in a key of a ConfigMap
+// +k8s:openapi-gen=true
+type PolicyConfigMapReference struct {
+	// Name of the ConfigMap
+	Name string `json:"name"`
+	// Key of the policy in the ConfigMap, policy.yaml by default
+	Key string `json:"key,omitempty"`
+}
+
+// DeschedulerProfile is a profile of a v1alpha2 policy, its strategies are rendered as plugins with their args
+// +k8s:openapi-gen=true
+type DeschedulerProfile struct {
+	// Name of the profile, unique in the descheduler
+	Name string `json:"name"`
+	// Strategies enabled in the profile, a strategy left unset is disabled
+	Strategies DeschedulerStrategies `json:"strategies"`
+	// PriorityThreshold keeps the pods of the threshold priority or above from being evicted by the profile, the
+	// threshold of the spec applies when it isn't set
+	PriorityThreshold `json:",inline"`
+}
+
+// PriorityThreshold is the priority from which pods are not evicted, either a value or the value of a PriorityClass
+// +k8s:openapi-gen=true
+type PriorityThreshold struct {
+	// ThresholdPriority is the priority from which pods are not evicted, it can't be set with thresholdPriorityClassName
+	ThresholdPriority *int32 `json:"thresholdPriority,omitempty"`
+	// ThresholdPriorityClassName is the PriorityClass whose value is the threshold priority
+	ThresholdPriorityClassName string `json:"thresholdPriorityClassName,omitempty"`
+}
+
+// IsSet returns true when the threshold is set by a value or a PriorityClass
+func (t PriorityThreshold) IsSet() bool {
+	return t.ThresholdPriority != nil || len(t.ThresholdPriorityClassName) != 0
+}
+
+// EvictorOptions are the options of the descheduler evictor, they apply to every strategy
+// +k8s:openapi-gen=true
+type EvictorOptions struct {
+	// EvictLocalStoragePods allows evicting pods using local storage
+	EvictLocalStoragePods bool `json:"evictLocalStoragePods,omitempty"`
+	// EvictSystemCriticalPods allows evicting system critical pods, and pods of any priority
+	EvictSystemCriticalPods bool `json:"evictSystemCriticalPods,omitempty"`
+	// IgnorePvcPods keeps pods with PersistentVolumeClaims from being evicted
+	IgnorePvcPods bool `json:"ignorePvcPods,omitempty"`
+	// MaxNoOfPodsToEvictPerNode is the maximum number of pods evicted from a node in a run, unlimited when unset or 0
+	// +kubebuilder:validation:Minimum=0
+	MaxNoOfPodsToEvictPerNode *int32 `json:"maxNoOfPodsToEvictPerNode,omitempty"`
+	// MaxNoOfPodsToEvictPerNamespace is the maximum number of pods evicted from a namespace in a run, unlimited
+	// when unset or 0
+	// +kubebuilder:validation:Minimum=0
+	MaxNoOfPodsToEvictPerNamespace *int32 `json:"maxNoOfPodsToEvictPerNamespace,omitempty"`
+}
+
+// PolicyVersion is an API version of the descheduler policy
+type PolicyVersion string
+
+// Policy versions the operator renders
+const (
+	// PolicyVersionV1alpha1 renders the strategies of a descheduler/v1alpha1 policy
+	PolicyVersionV1alpha1 PolicyVersion = "v1alpha1"
+	// PolicyVersionV1alpha2 renders the profiles and plugins of a descheduler/v1alpha2 policy
+	PolicyVersionV1alpha2 PolicyVersion = "v1alpha2"
+)
+
+// DefaultProfileName is the name of the v1alpha2 profile running spec.strategies
+const DefaultProfileName = "default"
+
+// DeschedulerMode is how descheduler runs
+type DeschedulerMode string
+
+// Modes of a Descheduler
+const (
+	// ModeCronJob runs descheduler once per spec.schedule from a CronJob
+	ModeCronJob DeschedulerMode = "CronJob"
+	// ModeDeployment runs descheduler in a Deployment, looping every descheduling-interval
+	ModeDeployment DeschedulerMode = "Deployment"
+	// ModeJob runs descheduler once in a Job, again whenever the spec changes
+	ModeJob DeschedulerMode = "Job"
+)
+
+// AdoptPolicy is what the operator does with existing resources it didn't create
+type AdoptPolicy string
+
+// Adopt policies of a Descheduler
+const (
+	// AdoptPolicyAdopt sets the Descheduler as the controller of existing resources without one, they are then
+	// updated to match the spec and garbage collected with the Descheduler
+	AdoptPolicyAdopt AdoptPolicy = "Adopt"
+	// AdoptPolicyRefuse leaves existing resources untouched and sets the Conflict condition
+	AdoptPolicyRefuse AdoptPolicy = "Refuse"
+)
+
+// RunRetention bounds the DeschedulerRun records of a Descheduler, records beyond either limit are deleted
+// +k8s:openapi-gen=true
+type RunRetention struct {
+	// MaxCount is the number of records kept
+	// +kubebuilder:validation:Minimum=1
+	MaxCount *int32 `json:"maxCount,omitempty"`
+	// MaxAge is how long a record is kept after its run finished
+	MaxAge *metav1.Duration `json:"maxAge,omitempty"`
+}
+
+// DeschedulerStrategies holds the typed parameters of every strategy supported by descheduler
+// +k8s:openapi-gen=true
+type DeschedulerStrategies struct {
+	// RemoveDuplicates evicts pods of the same owner running on the same node
+	RemoveDuplicates *RemoveDuplicatesStrategy `json:"removeDuplicates,omitempty"`
+	// LowNodeUtilization evicts pods from overutilized nodes so they can land on underutilized ones
+	LowNodeUtilization *LowNodeUtilizationStrategy `json:"lowNodeUtilization,omitempty"`
+	// RemovePodsViolatingInterPodAntiAffinity evicts pods violating inter-pod anti-affinity rules
+	RemovePodsViolatingInterPodAntiAffinity *RemovePodsViolatingInterPodAntiAffinityStrategy `json:"removePodsViolatingInterPodAntiAffinity,omitempty"`
+	// RemovePodsViolatingNodeAffinity evicts pods which no longer satisfy their node affinity
+	RemovePodsViolatingNodeAffinity *RemovePodsViolatingNodeAffinityStrategy `json:"removePodsViolatingNodeAffinity,omitempty"`
+	// RemovePodsViolatingNodeTaints evicts pods which don't tolerate the NoSchedule taints of their node
+	RemovePodsViolatingNodeTaints *RemovePodsViolatingNodeTaintsStrategy `json:"removePodsViolatingNodeTaints,omitempty"`
+	// RemovePodsHavingTooManyRestarts evicts pods whose containers restarted too many times
+	RemovePodsHavingTooManyRestarts *RemovePodsHavingTooManyRestartsStrategy `json:"removePodsHavingTooManyRestarts,omitempty"`
+	// PodLifeTime evicts pods older than a maximum lifetime
+	PodLifeTime *PodLifeTimeStrategy `json:"podLifeTime,omitempty"`
+	// RemovePodsViolatingTopologySpreadConstraint evicts pods so their topology spread constraints are satisfied
+	RemovePodsViolatingTopologySpreadConstraint *RemovePodsViolatingTopologySpreadConstraintStrategy `json:"removePodsViolatingTopologySpreadConstraint,omitempty"`
+	// HighNodeUtilization evicts pods from underutilized nodes so they can be scaled down
+	HighNodeUtilization *HighNodeUtilizationStrategy `json:"highNodeUtilization,omitempty"`
+	// RemoveFailedPods evicts pods in the Failed phase
+	RemoveFailedPods *RemoveFailedPodsStrategy `json:"removeFailedPods,omitempty"`
+}
+
+// RemoveDuplicatesStrategy configures the RemoveDuplicates strategy
+// +k8s:openapi-gen=true
+type RemoveDuplicatesStrategy struct {
+	// Namespaces restricts the namespaces the strategy evicts pods from
+	Namespaces *Namespaces `json:"namespaces,omitempty"`
+	// PriorityThreshold overrides the global priority threshold for the strategy
+	PriorityThreshold `json:",inline"`
+}
+
+// LowNodeUtilizationStrategy configures the LowNodeUtilization strategy
+// +k8s:openapi-gen=true
+type LowNodeUtilizationStrategy struct {
+	// Thresholds below which a node is considered underutilized
+	Thresholds ResourceThresholds `json:"thresholds,omitempty"`
+	// TargetThresholds above which a node is considered overutilized
+	TargetThresholds ResourceThresholds `json:"targetThresholds,omitempty"`
+	// NumberOfNodes is the number of underutilized nodes required before the strategy evicts pods
+	NumberOfNodes int32 `json:"numberOfNodes,omitempty"`
+	// PriorityThreshold overrides the global priority threshold for the strategy
+	PriorityThreshold `json:",inline"`
+}
+
+// ResourceThresholds are percentages of the node allocatable resources, a zero value is left unset
+// +k8s:openapi-gen=true
+type ResourceThresholds struct {
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	CPU int32 `json:"cpu,omitempty"`
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	Memory int32 `json:"memory,omitempty"`
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	Pods int32 `json:"pods,omitempty"`
+}
+
+// RemovePodsViolatingInterPodAntiAffinityStrategy configures the RemovePodsViolatingInterPodAntiAffinity strategy
+// +k8s:openapi-gen=true
+type RemovePodsViolatingInterPodAntiAffinityStrategy struct {
+	// Namespaces restricts the namespaces the strategy evicts pods from
+	Namespaces *Namespaces `json:"namespaces,omitempty"`
+	// PriorityThreshold overrides the global priority threshold for the strategy
+	PriorityThreshold `json:",inline"`
+}
+
+// RemovePodsViolatingNodeAffinityStrategy configures the RemovePodsViolatingNodeAffinity strategy
+// +k8s:openapi-gen=true
+type RemovePodsViolatingNodeAffinityStrategy struct {
+	// NodeAffinityType lists the node affinity types to check, defaults to requiredDuringSchedulingIgnoredDuringExecution
+	NodeAffinityType []string `json:"nodeAffinityType,omitempty"`
+	// Namespaces restricts the namespaces the strategy evicts pods from
+	Namespaces *Namespaces `json:"namespaces,omitempty"`
+	// PriorityThreshold overrides the global priority threshold for the strategy
+	PriorityThreshold `json:",inline"`
+}
+
+// RemovePodsViolatingNodeTaintsStrategy configures the RemovePodsViolatingNodeTaints strategy
+// +k8s:openapi-gen=true
+type RemovePodsViolatingNodeTaintsStrategy struct {
+	// ExcludedTaints are the taints ignored by the strategy, either a key or a key=value
+	ExcludedTaints []string `json:"excludedTaints,omitempty"`
+	// IncludePreferNoSchedule also evicts pods which don't tolerate the PreferNoSchedule taints of their node
+	IncludePreferNoSchedule bool `json:"includePreferNoSchedule,omitempty"`
+	// Namespaces restricts the namespaces the strategy evicts pods from
+	Namespaces *Namespaces `json:"namespaces,omitempty"`
+	// PriorityThreshold overrides the global priority threshold for the strategy
+	PriorityThreshold `json:",inline"`
+}
+
+// RemovePodsHavingTooManyRestartsStrategy configures the RemovePodsHavingTooManyRestarts strategy
+// +k8s:openapi-gen=true
+type RemovePodsHavingTooManyRestartsStrategy struct {
+	// PodRestartThreshold is the number of restarts of the containers of a pod from which it is evicted
+	// +kubebuilder:validation:Minimum=1
+	PodRestartThreshold int32 `json:"podRestartThreshold"`
+	// IncludingInitContainers also counts the restarts of the init containers
+	IncludingInitContainers bool `json:"includingInitContainers,omitempty"`
+	// Namespaces restricts the namespaces the strategy evicts pods from
+	Namespaces *Namespaces `json:"namespaces,omitempty"`
+	// PriorityThreshold overrides the global priority threshold for the strategy
+	PriorityThreshold `json:",inline"`
+}
+
+// PodLifeTimeStrategy configures the PodLifeTime strategy
+// +k8s:openapi-gen=true
+type PodLifeTimeStrategy struct {
+	// MaxPodLifeTimeSeconds is the age from which pods are evicted
+	// +kubebuilder:validation:Minimum=1
+	MaxPodLifeTimeSeconds int64 `json:"maxPodLifeTimeSeconds"`
+	// PodStatusPhases restricts the strategy to the pods in these phases, Pending or Running
+	PodStatusPhases []corev1.PodPhase `json:"podStatusPhases,omitempty"`
+	// Namespaces restricts the namespaces the strategy evicts pods from
+	Namespaces *Namespaces `json:"namespaces,omitempty"`
+	// PriorityThreshold overrides the global priority threshold for the strategy
+	PriorityThreshold `json:",inline"`
+}
+
+// RemovePodsViolatingTopologySpreadConstraintStrategy configures the RemovePodsViolatingTopologySpreadConstraint strategy
+// +k8s:openapi-gen=true
+type RemovePodsViolatingTopologySpreadConstraintStrategy struct {
+	// IncludeSoftConstraints also balances the constraints with whenUnsatisfiable set to ScheduleAnyway
+	IncludeSoftConstraints bool `json:"includeSoftConstraints,omitempty"`
+	// Namespaces restricts the namespaces the strategy evicts pods from
+	Namespaces *Namespaces `json:"namespaces,omitempty"`
+	// PriorityThreshold overrides the global priority threshold for the strategy
+	PriorityThreshold `json:",inline"`
+}
+
+// HighNodeUtilizationStrategy configures the HighNodeUtilization strategy
+// +k8s:openapi-gen=true
+type HighNodeUtilizationStrategy struct {
+	// Thresholds below which a node is considered underutilized and its pods evicted
+	Thresholds ResourceThresholds `json:"thresholds,omitempty"`
+	// NumberOfNodes is the number of underutilized nodes required before the strategy evicts pods
+	NumberOfNodes int32 `json:"numberOfNodes,omitempty"`
+	// PriorityThreshold overrides the global priority threshold for the strategy
+	PriorityThreshold `json:",inline"`
+}
+
+// RemoveFailedPodsStrategy configures the RemoveFailedPods strategy
+// +k8s:openapi-gen=true
+type RemoveFailedPodsStrategy struct {
+	// Reasons restricts the strategy to the pods failed for one of these reasons, of the pod or of a container
+	Reasons []string `json:"reasons,omitempty"`
+	// IncludingInitContainers also matches the reasons of the init containers
+	IncludingInitContainers bool `json:"includingInitContainers,omitempty"`
+	// ExcludeOwnerKinds are the kinds of owners whose pods are never evicted, e.g. Job
+	ExcludeOwnerKinds []string `json:"excludeOwnerKinds,omitempty"`
+	// MinPodLifetimeSeconds is the age below which failed pods are kept
+	// +kubebuilder:validation:Minimum=0
+	MinPodLifetimeSeconds int64 `json:"minPodLifetimeSeconds,omitempty"`
+	// Namespaces restricts the namespaces the strategy evicts pods from
+	Namespaces *Namespaces `json:"namespaces,omitempty"`
+	// PriorityThreshold overrides the global priority threshold for the strategy
+	PriorityThreshold `json:",inline"`
+}
+
+// Namespaces filters the namespaces of the pods a strategy evicts, either to the included namespaces or to all but
+// the excluded ones
+// +k8s:openapi-gen=true
+type Namespaces struct {
+	// Include lists the only namespaces pods are evicted from, it can't be set with exclude
+	Include []string `json:"include,omitempty"`
+	// Exclude lists the namespaces pods are never evicted from, it can't be set with include
+	Exclude []string `json:"exclude,omitempty"`
+}
+
+// Param is a key/value pair representing a descheduler flag
+// +k8s:openapi-gen=true
+type Param struct {
+	Name  string `json:"name,omitempty"`
+	Value string `json:"value,omitempty"`
+}
+
+// DeschedulerStatus defines the observed state of Descheduler
+// +k8s:openapi-gen=true
+type DeschedulerStatus struct {
+	// Phase is kept for v1alpha1 clients, use Conditions instead
+	Phase string `json:"phase,omitempty"`
+	// ObservedGeneration is the generation of the Descheduler last reconciled by the operator
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// Conditions describe why the descheduler is or is not healthy
+	// +patchMergeKey=type
+	// +patchStrategy=merge
+	Conditions []DeschedulerCondition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
+	// LastScheduleTime is the last time the CronJob of the descheduler started a Job
+	LastScheduleTime *metav1.Time `json:"lastScheduleTime,omitempty"`
+	// LastSuccessfulTime is the completion time of the last descheduler Job that succeeded
+	LastSuccessfulTime *metav1.Time `json:"lastSuccessfulTime,omitempty"`
+	// Runs are the last descheduler Jobs, most recent first
+	Runs []RunSummary `json:"runs,omitempty"`
+	// LastEvictions summarizes the pods evicted by the last finished descheduler Job, read from its logs
+	LastEvictions *EvictionSummary `json:"lastEvictions,omitempty"`
+	// ImageFallback is set while the CronJob runs the default image because spec.image failed to pull
+	ImageFallback *ImageFallback `json:"imageFallback,omitempty"`
+	// LastManualRun is the last run triggered with the RunNowAnnotation
+	LastManualRun *ManualRun `json:"lastManualRun,omitempty"`
+	// DryRunReport lists the pods the last finished dry run would have evicted
+	DryRunReport *DryRunReport `json:"dryRunReport,omitempty"`
+}
+
+// DryRunReport is the outcome of a descheduler Job run with --dry-run
+// +k8s:openapi-gen=true
+type DryRunReport struct {
+	// PolicyHash is the sha256 of the policy.yaml the Job ran with
+	PolicyHash string `json:"policyHash,omitempty"`
+	// CompletionTime is the time the Job finished
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+	// ConfigMapName is the ConfigMap holding the full report, up to 1000 pods
+	ConfigMapName string `json:"configMapName"`
+	// Evictions are the pods descheduler would have evicted, the first 50 are listed
+	Evictions EvictionSummary `json:"evictions"`
+}
+
+// RunNowAnnotation triggers a run of descheduler outside of the schedule, each time it is set to a new token
+const RunNowAnnotation = "descheduler.axway.com/run-now"
+
+// ManualRun is a run triggered with the RunNowAnnotation
+// +k8s:openapi-gen=true
+type ManualRun struct {
+	// Token is the value of the annotation that triggered the run
+	Token string `json:"token"`
+	// JobName is the name of the Job created for the run, empty when the run was skipped
+	JobName string `json:"jobName,omitempty"`
+	// TriggeredAt is the time the Job was created
+	TriggeredAt metav1.Time `json:"triggeredAt"`
+	// Result is Active while the Job runs, then Succeeded or Failed. It is Skipped when the Job couldn't be created,
+	// e.g. while the descheduler is suspended.
+	Result DeschedulerRunResult `json:"result,omitempty"`
+	// Reason tells why the run was skipped
+	Reason string `json:"reason,omitempty"`
+}
+
+// ImageFallback tells why the CronJob runs the default image instead of spec.image
+// +k8s:openapi-gen=true
+type ImageFallback struct {
+	// FailedImage is the spec.image that failed to pull, the fallback ends when spec.image changes
+	FailedImage string `json:"failedImage"`
+	// Image is the image the CronJob runs instead
+	Image string `json:"image"`
+	// Reason is why the pod of the last failed run was waiting, e.g. ImagePullBackOff
+	Reason string `json:"reason,omitempty"`
+	// Since is the time the operator switched to the fallback image
+	Since metav1.Time `json:"since"`
+}
+
+// EvictionSummary counts the pods evicted by a descheduler Job
+// +k8s:openapi-gen=true
+type EvictionSummary struct {
+	// JobName is the name of the Job the logs were read from
+	JobName string `json:"jobName"`
+	// Total is the number of evicted pods
+	Total int32 `json:"total"`
+	// ByStrategy is the number of evicted pods per strategy, pods are counted as unknown when the
+	// descheduler logs don't tell the strategy
+	ByStrategy map[string]int32 `json:"byStrategy,omitempty"`
+	// ByNamespace is the number of evicted pods per namespace
+	ByNamespace map[string]int32 `json:"byNamespace,omitempty"`
+	// ByNode is the number of evicted pods per node
+	ByNode map[string]int32 `json:"byNode,omitempty"`
+	// EvictedPods lists the first evicted pods, it is truncated to keep the status small
+	EvictedPods []EvictedPod `json:"evictedPods,omitempty"`
+	// Error tells why the logs of the Job couldn't be read, the evictions are then unknown and not read again
+	Error string `json:"error,omitempty"`
+}
+
+// EvictedPod is a pod evicted by descheduler
+// +k8s:openapi-gen=true
+type EvictedPod struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace,omitempty"`
+	Node      string `json:"node,omitempty"`
+	Strategy  string `json:"strategy,omitempty"`
+}
+
+// DeschedulerRunResult is the outcome of a descheduler Job
+type DeschedulerRunResult string
+
+// Results of a descheduler run
+const (
+	RunActive    DeschedulerRunResult = "Active"
+	RunSucceeded DeschedulerRunResult = "Succeeded"
+	RunFailed    DeschedulerRunResult = "Failed"
+	// RunSkipped is the result of a manual run requested while the descheduler is suspended
+	RunSkipped DeschedulerRunResult = "Skipped"
+	// RunSuperseded is the result of a Job mode run deleted before it finished because the spec changed, a new Job
+	// runs with the new spec
+	RunSuperseded DeschedulerRunResult = "Superseded"
+)
+
+// RunSummary describes one descheduler Job created by the CronJob
+// +k8s:openapi-gen=true
+type RunSummary struct {
+	// JobName is the name of the Job
+	JobName string `json:"jobName"`
+	// PodName is the name of the last pod of the Job
+	PodName string `json:"podName,omitempty"`
+	// StartTime is the time the Job started
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+	// CompletionTime is the time the Job succeeded or failed
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+	// Duration is the time the Job took to finish
+	Duration *metav1.Duration `json:"duration,omitempty"`
+	// Result is Active while the Job runs, then Succeeded or Failed, or Superseded
+	Result DeschedulerRunResult `json:"result"`
+	// Image is the descheduler image the Job runs
+	Image string `json:"image,omitempty"`
+	// FailureReason tells why the Job failed or why its pod can't start, e.g. ImagePullBackOff
+	FailureReason string `json:"failureReason,omitempty"`
+	// Manual is true for the Jobs triggered with the RunNowAnnotation
+	Manual bool `json:"manual,omitempty"`
+	// DryRun is true for the Jobs run with --dry-run, they evict no pod
+	DryRun bool `json:"dryRun,omitempty"`
+}
+
+// DeschedulerConditionType is the type of a DeschedulerCondition
+type DeschedulerConditionType string
+
+// Condition types reported in the status of a Descheduler
+const (
+	// ConditionReady is true when the policy is valid, the CronJob is scheduled and the operator is not degraded
+	ConditionReady DeschedulerConditionType = "Ready"
+	// ConditionPolicyValid is true when the spec passed validation and the policy was rendered
+	ConditionPolicyValid DeschedulerConditionType = "PolicyValid"
+	// ConditionWorkloadReady is true when the CronJob, Deployment or Job of the mode exists and matches the spec
+	ConditionWorkloadReady DeschedulerConditionType = "WorkloadReady"
+	// ConditionCronJobReady is WorkloadReady, only set in CronJob mode.
+	// Deprecated: use ConditionWorkloadReady
+	ConditionCronJobReady DeschedulerConditionType = "CronJobReady"
+	// ConditionLastRunSucceeded reflects the outcome of the last finished descheduler Job
+	ConditionLastRunSucceeded DeschedulerConditionType = "LastRunSucceeded"
+	// ConditionDegraded is true when the operator failed to reconcile the Descheduler
+	ConditionDegraded DeschedulerConditionType = "Degraded"
+	// ConditionSuspended is true while spec.suspend is set
+	ConditionSuspended DeschedulerConditionType = "Suspended"
+	// ConditionConflict is true when a resource named after the Descheduler exists and the operator doesn't control it
+	ConditionConflict DeschedulerConditionType = "Conflict"
+	// ConditionImageCompatible is true when the descheduler release of spec.image supports every setting of the spec,
+	// unknown when the image tag isn't a release version
+	ConditionImageCompatible DeschedulerConditionType = "ImageCompatible"
+)
+
+// DeschedulerCondition describes the state of a Descheduler at a certain point
+// +k8s:openapi-gen=true
+type DeschedulerCondition struct {
+	// Type of the condition
+	Type DeschedulerConditionType `json:"type"`
+	// Status of the condition, one of True, False, Unknown
+	Status corev1.ConditionStatus `json:"status"`
+	// ObservedGeneration is the generation of the Descheduler the condition was set for
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// LastTransitionTime is the last time the condition changed from one status to another
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+	// Reason is a CamelCase reason for the last transition
+	Reason string `json:"reason,omitempty"`
+	// Message is a human readable message about the last transition
+	Message string `json:"message,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// Descheduler is the Schema for the deschedulers API
+// +k8s:openapi-gen=true
+// +kubebuilder:subresource:status
+// +kubebuilder:storageversion
+type Descheduler struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   DeschedulerSpec   `json:"spec,omitempty"`
+	Status DeschedulerStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// DeschedulerList contains a list of Descheduler
+type DeschedulerList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Descheduler `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Descheduler{}, &DeschedulerList{})
+}
@@ -0,0 +1,496 @@
+package v1beta1
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/robfig/cron"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// AllowedFlags are the descheduler flags that can be set through spec.flags
+var AllowedFlags = []string{FlagDeschedulingInterval, FlagDryRun, FlagNodeSelector}
+
+// FlagDeschedulingInterval makes descheduler loop forever, running its strategies every interval
+const FlagDeschedulingInterval = "descheduling-interval"
+
+// FlagDryRun makes descheduler log the pods it would evict without evicting them, spec.dryRun sets it
+const FlagDryRun = "dry-run"
+
+// FlagNodeSelector restricts descheduler to the nodes matching the label selector
+const FlagNodeSelector = "node-selector"
+
+// NodeAffinityTypeRequired is the only node affinity type descheduler is able to check
+const NodeAffinityTypeRequired = "requiredDuringSchedulingIgnoredDuringExecution"
+
+// Validate checks the Descheduler and returns an error describing every invalid field, or nil.
+func (d *Descheduler) Validate() error {
+	return d.ValidateFields().ToAggregate()
+}
+
+// ValidateFields returns the list of invalid fields of the Descheduler
+func (d *Descheduler) ValidateFields() field.ErrorList {
+	allErrs := field.ErrorList{}
+	specPath := field.NewPath("spec")
+	mode := d.Spec.Mode
+	if len(mode) == 0 {
+		mode = ModeCronJob
+	}
+	switch mode {
+	case ModeCronJob, ModeDeployment, ModeJob:
+	default:
+		allErrs = append(allErrs, field.NotSupported(specPath.Child("mode"), mode,
+			[]string{string(ModeCronJob), string(ModeDeployment), string(ModeJob)}))
+	}
+	switch d.Spec.AdoptExisting {
+	case "", AdoptPolicyAdopt, AdoptPolicyRefuse:
+	default:
+		allErrs = append(allErrs, field.NotSupported(specPath.Child("adoptExisting"), d.Spec.AdoptExisting,
+			[]string{string(AdoptPolicyAdopt), string(AdoptPolicyRefuse)}))
+	}
+	// The schedule is only used by the CronJob, validate it anyway when set
+	if mode == ModeCronJob || len(d.Spec.Schedule) != 0 {
+		allErrs = append(allErrs, validateSchedule(d.Spec.Schedule, specPath.Child("schedule"))...)
+	}
+	switch d.Spec.PolicyVersion {
+	case "", PolicyVersionV1alpha1, PolicyVersionV1alpha2:
+	default:
+		allErrs = append(allErrs, field.NotSupported(specPath.Child("policyVersion"), d.Spec.PolicyVersion,
+			[]string{string(PolicyVersionV1alpha1), string(PolicyVersionV1alpha2)}))
+	}
+	if d.Spec.Policy != nil {
+		allErrs = append(allErrs, validatePolicySource(d, specPath)...)
+	} else if len(d.Spec.Profiles) == 0 {
+		allErrs = append(allErrs, validateStrategies(d.Spec.Strategies, specPath.Child("strategies"))...)
+		allErrs = append(allErrs, validateStrategyPriorityThresholds(d.Spec.Strategies, d.Spec.PolicyVersion, specPath.Child("strategies"))...)
+	} else {
+		allErrs = append(allErrs, validateProfiles(d.Spec, specPath)...)
+	}
+	allErrs = append(allErrs, validatePriorityThreshold(d.Spec.PriorityThreshold, specPath)...)
+	allErrs = append(allErrs, validateFlags(d.Spec.Flags, specPath.Child("flags"))...)
+	allErrs = append(allErrs, validateDeschedulingInterval(mode, d.Spec.Flags, specPath.Child("flags"))...)
+	if d.Spec.DryRun {
+		for i, flag := range d.Spec.Flags {
+			if flag.Name == FlagDryRun {
+				allErrs = append(allErrs, field.Forbidden(specPath.Child("flags").Index(i).Child("name"),
+					fmt.Sprintf("%s is set by spec.dryRun", FlagDryRun)))
+			}
+		}
+	}
+	if d.Spec.LogVerbosity != nil && (*d.Spec.LogVerbosity < 0 || *d.Spec.LogVerbosity > 10) {
+		allErrs = append(allErrs, field.Invalid(specPath.Child("logVerbosity"), *d.Spec.LogVerbosity, "must be between 0 and 10"))
+	}
+	if d.Spec.ImagePullFailureThreshold != nil && *d.Spec.ImagePullFailureThreshold < 0 {
+		allErrs = append(allErrs, field.Invalid(specPath.Child("imagePullFailureThreshold"), *d.Spec.ImagePullFailureThreshold,
+			"must be greater than or equal to 0"))
+	}
+	if max := d.Spec.MaxNoOfPodsToEvictPerNode; max != nil && *max < 0 {
+		allErrs = append(allErrs, field.Invalid(specPath.Child("maxNoOfPodsToEvictPerNode"), *max, "must be greater than or equal to 0"))
+	}
+	if max := d.Spec.MaxNoOfPodsToEvictPerNamespace; max != nil && *max < 0 {
+		allErrs = append(allErrs, field.Invalid(specPath.Child("maxNoOfPodsToEvictPerNamespace"), *max, "must be greater than or equal to 0"))
+	}
+	if limit := d.Spec.RunHistoryLimit; limit != nil && (*limit < 1 || *limit > 100) {
+		allErrs = append(allErrs, field.Invalid(specPath.Child("runHistoryLimit"), *limit, "must be between 1 and 100"))
+	}
+	if retention := d.Spec.RunRetention; retention != nil {
+		retentionPath := specPath.Child("runRetention")
+		if retention.MaxCount != nil && *retention.MaxCount < 1 {
+			allErrs = append(allErrs, field.Invalid(retentionPath.Child("maxCount"), *retention.MaxCount, "must be greater than or equal to 1"))
+		}
+		if retention.MaxAge != nil && retention.MaxAge.Duration <= 0 {
+			allErrs = append(allErrs, field.Invalid(retentionPath.Child("maxAge"), retention.MaxAge.Duration.String(), "must be greater than 0"))
+		}
+	}
+	return allErrs
+}
+
+func validateSchedule(schedule string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if len(schedule) == 0 {
+		return append(allErrs, field.Required(fldPath, "descheduler should have schedule for cron job set"))
+	}
+	if _, err := cron.ParseStandard(schedule); err != nil {
+		allErrs = append(allErrs, field.Invalid(fldPath, schedule, err.Error()))
+	}
+	return allErrs
+}
+
+func validateStrategies(strategies DeschedulerStrategies, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if strategies == (DeschedulerStrategies{}) {
+		allErrs = append(allErrs, field.Required(fldPath, "descheduler should have atleast one strategy enabled"))
+	}
+	if strategies.LowNodeUtilization != nil {
+		allErrs = append(allErrs, validateLowNodeUtilization(strategies.LowNodeUtilization, fldPath.Child("lowNodeUtilization"))...)
+	}
+	if strategies.RemovePodsViolatingNodeAffinity != nil {
+		typesPath := fldPath.Child("removePodsViolatingNodeAffinity", "nodeAffinityType")
+		for i, nodeAffinityType := range strategies.RemovePodsViolatingNodeAffinity.NodeAffinityType {
+			if nodeAffinityType != NodeAffinityTypeRequired {
+				allErrs = append(allErrs, field.NotSupported(typesPath.Index(i), nodeAffinityType, []string{NodeAffinityTypeRequired}))
+			}
+		}
+	}
+	if nodeTaints := strategies.RemovePodsViolatingNodeTaints; nodeTaints != nil {
+		taintsPath := fldPath.Child("removePodsViolatingNodeTaints", "excludedTaints")
+		for i, taint := range nodeTaints.ExcludedTaints {
+			if len(strings.SplitN(taint, "=", 2)[0]) == 0 {
+				allErrs = append(allErrs, field.Invalid(taintsPath.Index(i), taint, "must be a taint key or key=value"))
+			}
+		}
+	}
+	if tooManyRestarts := strategies.RemovePodsHavingTooManyRestarts; tooManyRestarts != nil && tooManyRestarts.PodRestartThreshold < 1 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("removePodsHavingTooManyRestarts", "podRestartThreshold"),
+			tooManyRestarts.PodRestartThreshold, "must be greater than or equal to 1"))
+	}
+	if podLifeTime := strategies.PodLifeTime; podLifeTime != nil {
+		podLifeTimePath := fldPath.Child("podLifeTime")
+		if podLifeTime.MaxPodLifeTimeSeconds < 1 {
+			allErrs = append(allErrs, field.Invalid(podLifeTimePath.Child("maxPodLifeTimeSeconds"), podLifeTime.MaxPodLifeTimeSeconds,
+				"must be greater than or equal to 1"))
+		}
+		for i, phase := range podLifeTime.PodStatusPhases {
+			if phase != corev1.PodPending && phase != corev1.PodRunning {
+				allErrs = append(allErrs, field.NotSupported(podLifeTimePath.Child("podStatusPhases").Index(i), phase,
+					[]string{string(corev1.PodPending), string(corev1.PodRunning)}))
+			}
+		}
+	}
+	if highNodeUtilization := strategies.HighNodeUtilization; highNodeUtilization != nil {
+		highNodeUtilizationPath := fldPath.Child("highNodeUtilization")
+		if highNodeUtilization.Thresholds == (ResourceThresholds{}) {
+			allErrs = append(allErrs, field.Required(highNodeUtilizationPath.Child("thresholds"), "at least one threshold must be set"))
+		}
+		allErrs = append(allErrs, validateResourceThresholds(highNodeUtilization.Thresholds, highNodeUtilizationPath.Child("thresholds"))...)
+		if highNodeUtilization.NumberOfNodes < 0 {
+			allErrs = append(allErrs, field.Invalid(highNodeUtilizationPath.Child("numberOfNodes"), highNodeUtilization.NumberOfNodes,
+				"must be greater than or equal to 0"))
+		}
+	}
+	if failedPods := strategies.RemoveFailedPods; failedPods != nil && failedPods.MinPodLifetimeSeconds < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("removeFailedPods", "minPodLifetimeSeconds"), failedPods.MinPodLifetimeSeconds,
+			"must be greater than or equal to 0"))
+	}
+	for _, filter := range namespaceFilters(strategies) {
+		allErrs = append(allErrs, validateNamespaces(filter.namespaces, fldPath.Child(filter.strategy, "namespaces"))...)
+	}
+	return allErrs
+}
+
+func validateProfiles(spec DeschedulerSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	profilesPath := fldPath.Child("profiles")
+	if spec.PolicyVersion != PolicyVersionV1alpha2 {
+		allErrs = append(allErrs, field.Forbidden(profilesPath, fmt.Sprintf("profiles need policyVersion %s", PolicyVersionV1alpha2)))
+	}
+	if spec.Strategies != (DeschedulerStrategies{}) {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("strategies"),
+			"strategies and profiles are mutually exclusive, set the strategies of the profiles"))
+	}
+	names := map[string]bool{}
+	for i, profile := range spec.Profiles {
+		profilePath := profilesPath.Index(i)
+		if len(profile.Name) == 0 {
+			allErrs = append(allErrs, field.Required(profilePath.Child("name"), "profile should have a name"))
+		} else if names[profile.Name] {
+			allErrs = append(allErrs, field.Duplicate(profilePath.Child("name"), profile.Name))
+		}
+		names[profile.Name] = true
+		allErrs = append(allErrs, validateStrategies(profile.Strategies, profilePath.Child("strategies"))...)
+		allErrs = append(allErrs, validateStrategyPriorityThresholds(profile.Strategies, PolicyVersionV1alpha2, profilePath.Child("strategies"))...)
+		allErrs = append(allErrs, validatePriorityThreshold(profile.PriorityThreshold, profilePath)...)
+	}
+	return allErrs
+}
+
+// validatePolicySource checks spec.policy, the policy it refers to is parsed by the operator. The settings rendered
+// in the policy can't be set with it.
+func validatePolicySource(d *Descheduler, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	policyPath := fldPath.Child("policy")
+	policy := d.Spec.Policy
+	if policy.Raw == nil && policy.ConfigMapRef == nil {
+		allErrs = append(allErrs, field.Required(policyPath, "one of raw and configMapRef must be set"))
+	} else if policy.Raw != nil && policy.ConfigMapRef != nil {
+		allErrs = append(allErrs, field.Forbidden(policyPath.Child("configMapRef"), "raw and configMapRef are mutually exclusive"))
+	}
+	if policy.Raw != nil && len(policy.Raw.Raw) == 0 {
+		allErrs = append(allErrs, field.Required(policyPath.Child("raw"), "raw must be a DeschedulerPolicy"))
+	}
+	if ref := policy.ConfigMapRef; ref != nil {
+		refPath := policyPath.Child("configMapRef")
+		if len(ref.Name) == 0 {
+			allErrs = append(allErrs, field.Required(refPath.Child("name"), "name of the ConfigMap holding the policy"))
+		} else if ref.Name == d.Name {
+			allErrs = append(allErrs, field.Invalid(refPath.Child("name"), ref.Name, "is the ConfigMap generated by the operator"))
+		} else {
+			for _, msg := range validation.IsDNS1123Subdomain(ref.Name) {
+				allErrs = append(allErrs, field.Invalid(refPath.Child("name"), ref.Name, msg))
+			}
+		}
+		for _, msg := range validation.IsConfigMapKey(ref.Key) {
+			allErrs = append(allErrs, field.Invalid(refPath.Child("key"), ref.Key, msg))
+		}
+	}
+	for _, setting := range []struct {
+		name string
+		set  bool
+	}{
+		{"strategies", d.Spec.Strategies != (DeschedulerStrategies{})},
+		{"profiles", len(d.Spec.Profiles) != 0},
+		{"policyVersion", len(d.Spec.PolicyVersion) != 0},
+		{"thresholdPriority", d.Spec.ThresholdPriority != nil},
+		{"thresholdPriorityClassName", len(d.Spec.ThresholdPriorityClassName) != 0},
+		{"evictLocalStoragePods", d.Spec.EvictLocalStoragePods},
+		{"evictSystemCriticalPods", d.Spec.EvictSystemCriticalPods},
+		{"ignorePvcPods", d.Spec.IgnorePvcPods},
+		{"maxNoOfPodsToEvictPerNode", d.Spec.MaxNoOfPodsToEvictPerNode != nil},
+		{"maxNoOfPodsToEvictPerNamespace", d.Spec.MaxNoOfPodsToEvictPerNamespace != nil},
+	} {
+		if setting.set {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child(setting.name), "is part of spec.policy, set it in the policy"))
+		}
+	}
+	return allErrs
+}
+
+// validateStrategyPriorityThresholds checks the priority thresholds of the strategies, a v1alpha2 policy only has
+// a threshold per profile
+func validateStrategyPriorityThresholds(strategies DeschedulerStrategies, version PolicyVersion, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	for _, threshold := range priorityThresholds(strategies) {
+		strategyPath := fldPath.Child(threshold.strategy)
+		if version == PolicyVersionV1alpha2 && threshold.threshold.IsSet() {
+			allErrs = append(allErrs, field.Forbidden(strategyPath,
+				fmt.Sprintf("policyVersion %s sets the priority threshold of a profile, not of its strategies", PolicyVersionV1alpha2)))
+			continue
+		}
+		allErrs = append(allErrs, validatePriorityThreshold(threshold.threshold, strategyPath)...)
+	}
+	return allErrs
+}
+
+// strategyNamespaces is the namespaces filter of a strategy
+type strategyNamespaces struct {
+	strategy   string
+	namespaces *Namespaces
+}
+
+// namespaceFilters returns the namespaces filters of the enabled strategies, the utilization strategies have none
+func namespaceFilters(strategies DeschedulerStrategies) []strategyNamespaces {
+	var filters []strategyNamespaces
+	if strategy := strategies.RemoveDuplicates; strategy != nil {
+		filters = append(filters, strategyNamespaces{"removeDuplicates", strategy.Namespaces})
+	}
+	if strategy := strategies.RemovePodsViolatingInterPodAntiAffinity; strategy != nil {
+		filters = append(filters, strategyNamespaces{"removePodsViolatingInterPodAntiAffinity", strategy.Namespaces})
+	}
+	if strategy := strategies.RemovePodsViolatingNodeAffinity; strategy != nil {
+		filters = append(filters, strategyNamespaces{"removePodsViolatingNodeAffinity", strategy.Namespaces})
+	}
+	if strategy := strategies.RemovePodsViolatingNodeTaints; strategy != nil {
+		filters = append(filters, strategyNamespaces{"removePodsViolatingNodeTaints", strategy.Namespaces})
+	}
+	if strategy := strategies.RemovePodsHavingTooManyRestarts; strategy != nil {
+		filters = append(filters, strategyNamespaces{"removePodsHavingTooManyRestarts", strategy.Namespaces})
+	}
+	if strategy := strategies.PodLifeTime; strategy != nil {
+		filters = append(filters, strategyNamespaces{"podLifeTime", strategy.Namespaces})
+	}
+	if strategy := strategies.RemovePodsViolatingTopologySpreadConstraint; strategy != nil {
+		filters = append(filters, strategyNamespaces{"removePodsViolatingTopologySpreadConstraint", strategy.Namespaces})
+	}
+	if strategy := strategies.RemoveFailedPods; strategy != nil {
+		filters = append(filters, strategyNamespaces{"removeFailedPods", strategy.Namespaces})
+	}
+	return filters
+}
+
+// strategyPriorityThreshold is the priority threshold of a strategy
+type strategyPriorityThreshold struct {
+	strategy  string
+	threshold PriorityThreshold
+}
+
+// priorityThresholds returns the priority thresholds of the enabled strategies, set or not
+func priorityThresholds(strategies DeschedulerStrategies) []strategyPriorityThreshold {
+	var thresholds []strategyPriorityThreshold
+	if strategy := strategies.RemoveDuplicates; strategy != nil {
+		thresholds = append(thresholds, strategyPriorityThreshold{"removeDuplicates", strategy.PriorityThreshold})
+	}
+	if strategy := strategies.LowNodeUtilization; strategy != nil {
+		thresholds = append(thresholds, strategyPriorityThreshold{"lowNodeUtilization", strategy.PriorityThreshold})
+	}
+	if strategy := strategies.RemovePodsViolatingInterPodAntiAffinity; strategy != nil {
+		thresholds = append(thresholds, strategyPriorityThreshold{"removePodsViolatingInterPodAntiAffinity", strategy.PriorityThreshold})
+	}
+	if strategy := strategies.RemovePodsViolatingNodeAffinity; strategy != nil {
+		thresholds = append(thresholds, strategyPriorityThreshold{"removePodsViolatingNodeAffinity", strategy.PriorityThreshold})
+	}
+	if strategy := strategies.RemovePodsViolatingNodeTaints; strategy != nil {
+		thresholds = append(thresholds, strategyPriorityThreshold{"removePodsViolatingNodeTaints", strategy.PriorityThreshold})
+	}
+	if strategy := strategies.RemovePodsHavingTooManyRestarts; strategy != nil {
+		thresholds = append(thresholds, strategyPriorityThreshold{"removePodsHavingTooManyRestarts", strategy.PriorityThreshold})
+	}
+	if strategy := strategies.PodLifeTime; strategy != nil {
+		thresholds = append(thresholds, strategyPriorityThreshold{"podLifeTime", strategy.PriorityThreshold})
+	}
+	if strategy := strategies.RemovePodsViolatingTopologySpreadConstraint; strategy != nil {
+		thresholds = append(thresholds, strategyPriorityThreshold{"removePodsViolatingTopologySpreadConstraint", strategy.PriorityThreshold})
+	}
+	if strategy := strategies.HighNodeUtilization; strategy != nil {
+		thresholds = append(thresholds, strategyPriorityThreshold{"highNodeUtilization", strategy.PriorityThreshold})
+	}
+	if strategy := strategies.RemoveFailedPods; strategy != nil {
+		thresholds = append(thresholds, strategyPriorityThreshold{"removeFailedPods", strategy.PriorityThreshold})
+	}
+	return thresholds
+}
+
+// PriorityClassNames returns the PriorityClasses the priority thresholds of the Descheduler refer to, sorted
+func (d *Descheduler) PriorityClassNames() []string {
+	seen := map[string]bool{}
+	var names []string
+	thresholds := append(priorityThresholds(d.Spec.Strategies), strategyPriorityThreshold{threshold: d.Spec.PriorityThreshold})
+	for _, profile := range d.Spec.Profiles {
+		thresholds = append(thresholds, priorityThresholds(profile.Strategies)...)
+		thresholds = append(thresholds, strategyPriorityThreshold{threshold: profile.PriorityThreshold})
+	}
+	for _, threshold := range thresholds {
+		name := threshold.threshold.ThresholdPriorityClassName
+		if len(name) != 0 && !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+func validatePriorityThreshold(threshold PriorityThreshold, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if threshold.ThresholdPriority != nil && len(threshold.ThresholdPriorityClassName) != 0 {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("thresholdPriorityClassName"),
+			"thresholdPriority and thresholdPriorityClassName are mutually exclusive"))
+	}
+	if name := threshold.ThresholdPriorityClassName; len(name) != 0 {
+		for _, msg := range validation.IsDNS1123Subdomain(name) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("thresholdPriorityClassName"), name, msg))
+		}
+	}
+	return allErrs
+}
+
+func validateNamespaces(namespaces *Namespaces, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if namespaces == nil {
+		return allErrs
+	}
+	if len(namespaces.Include) != 0 && len(namespaces.Exclude) != 0 {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("exclude"), "include and exclude are mutually exclusive"))
+	}
+	for _, list := range []struct {
+		name       string
+		namespaces []string
+	}{
+		{"include", namespaces.Include},
+		{"exclude", namespaces.Exclude},
+	} {
+		for i, namespace := range list.namespaces {
+			for _, msg := range validation.IsDNS1123Label(namespace) {
+				allErrs = append(allErrs, field.Invalid(fldPath.Child(list.name).Index(i), namespace, msg))
+			}
+		}
+	}
+	return allErrs
+}
+
+func validateLowNodeUtilization(strategy *LowNodeUtilizationStrategy, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	thresholdsPath := fldPath.Child("thresholds")
+	targetThresholdsPath := fldPath.Child("targetThresholds")
+	allErrs = append(allErrs, validateResourceThresholds(strategy.Thresholds, thresholdsPath)...)
+	allErrs = append(allErrs, validateResourceThresholds(strategy.TargetThresholds, targetThresholdsPath)...)
+
+	for _, resource := range []struct {
+		name      string
+		threshold int32
+		target    int32
+	}{
+		{"cpu", strategy.Thresholds.CPU, strategy.TargetThresholds.CPU},
+		{"memory", strategy.Thresholds.Memory, strategy.TargetThresholds.Memory},
+		{"pods", strategy.Thresholds.Pods, strategy.TargetThresholds.Pods},
+	} {
+		if resource.threshold != 0 && resource.target != 0 && resource.threshold > resource.target {
+			allErrs = append(allErrs, field.Invalid(thresholdsPath.Child(resource.name), resource.threshold,
+				fmt.Sprintf("must be less than or equal to %s (%d)", targetThresholdsPath.Child(resource.name), resource.target)))
+		}
+	}
+
+	if strategy.NumberOfNodes < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("numberOfNodes"), strategy.NumberOfNodes, "must be greater than or equal to 0"))
+	}
+	return allErrs
+}
+
+func validateResourceThresholds(thresholds ResourceThresholds, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	for _, resource := range []struct {
+		name  string
+		value int32
+	}{
+		{"cpu", thresholds.CPU},
+		{"memory", thresholds.Memory},
+		{"pods", thresholds.Pods},
+	} {
+		if resource.value < 0 || resource.value > 100 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child(resource.name), resource.value, "must be a percentage between 0 and 100"))
+		}
+	}
+	return allErrs
+}
+
+func validateFlags(flags []Param, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	for i, flag := range flags {
+		allowedFlag := false
+		for _, validFlag := range AllowedFlags {
+			if flag.Name == validFlag {
+				allowedFlag = true
+			}
+		}
+		if !allowedFlag {
+			allErrs = append(allErrs, field.NotSupported(fldPath.Index(i).Child("name"), flag.Name, AllowedFlags))
+		}
+	}
+	return allErrs
+}
+
+// validateDeschedulingInterval requires the descheduling-interval flag in Deployment mode and forbids it in the
+// other modes, where descheduler would never exit and overlap with the next run
+func validateDeschedulingInterval(mode DeschedulerMode, flags []Param, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	found := false
+	for i, flag := range flags {
+		if flag.Name != FlagDeschedulingInterval {
+			continue
+		}
+		found = true
+		if mode != ModeDeployment {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Index(i).Child("name"),
+				fmt.Sprintf("%s is only allowed in %s mode, descheduler would never exit in %s mode", FlagDeschedulingInterval, ModeDeployment, mode)))
+		} else if interval, err := time.ParseDuration(flag.Value); err != nil || interval <= 0 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Index(i).Child("value"), flag.Value, "must be a positive duration, e.g. 5m"))
+		}
+	}
+	if !found && mode == ModeDeployment {
+		allErrs = append(allErrs, field.Required(fldPath, fmt.Sprintf("%s must be set in %s mode", FlagDeschedulingInterval, ModeDeployment)))
+	}
+	return allErrs
+}
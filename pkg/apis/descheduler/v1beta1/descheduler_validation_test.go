@@ -0,0 +1,322 @@
+package v1beta1
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func int32Ptr(value int32) *int32 {
+	return &value
+}
+
+// validDescheduler returns a valid Descheduler in CronJob mode, the test cases change it
+func validDescheduler() *Descheduler {
+	return &Descheduler{
+		ObjectMeta: metav1.ObjectMeta{Name: "example-descheduler", Namespace: "kube-system"},
+		Spec: DeschedulerSpec{
+			Schedule: "*/30 * * * *",
+			Strategies: DeschedulerStrategies{
+				RemoveDuplicates: &RemoveDuplicatesStrategy{},
+				LowNodeUtilization: &LowNodeUtilizationStrategy{
+					Thresholds:       ResourceThresholds{CPU: 20, Memory: 20, Pods: 20},
+					TargetThresholds: ResourceThresholds{CPU: 50, Memory: 50, Pods: 50},
+				},
+			},
+		},
+	}
+}
+
+func TestValidateFields(t *testing.T) {
+	tests := []struct {
+		name   string
+		change func(d *Descheduler)
+		// errors are the type and path of the expected errors, empty when the Descheduler is valid
+		errors []string
+	}{
+		{
+			name:   "valid",
+			change: func(d *Descheduler) {},
+		},
+		{
+			name:   "cron schedule required in CronJob mode",
+			change: func(d *Descheduler) { d.Spec.Schedule = "" },
+			errors: []string{"FieldValueRequired spec.schedule"},
+		},
+		{
+			name:   "invalid cron schedule",
+			change: func(d *Descheduler) { d.Spec.Schedule = "every 5 minutes" },
+			errors: []string{"FieldValueInvalid spec.schedule"},
+		},
+		{
+			name:   "cron schedule with too many fields",
+			change: func(d *Descheduler) { d.Spec.Schedule = "0 */30 * * * *" },
+			errors: []string{"FieldValueInvalid spec.schedule"},
+		},
+		{
+			name: "schedule not required in Job mode",
+			change: func(d *Descheduler) {
+				d.Spec.Mode = ModeJob
+				d.Spec.Schedule = ""
+			},
+		},
+		{
+			name: "schedule validated when set in Job mode",
+			change: func(d *Descheduler) {
+				d.Spec.Mode = ModeJob
+				d.Spec.Schedule = "never"
+			},
+			errors: []string{"FieldValueInvalid spec.schedule"},
+		},
+		{
+			name:   "unknown mode",
+			change: func(d *Descheduler) { d.Spec.Mode = "DaemonSet" },
+			errors: []string{"FieldValueNotSupported spec.mode"},
+		},
+		{
+			name: "descheduling-interval required in Deployment mode",
+			change: func(d *Descheduler) {
+				d.Spec.Mode = ModeDeployment
+			},
+			errors: []string{"FieldValueRequired spec.flags"},
+		},
+		{
+			name: "descheduling-interval in Deployment mode",
+			change: func(d *Descheduler) {
+				d.Spec.Mode = ModeDeployment
+				d.Spec.Flags = []Param{{Name: FlagDeschedulingInterval, Value: "5m"}}
+			},
+		},
+		{
+			name: "descheduling-interval must be a positive duration",
+			change: func(d *Descheduler) {
+				d.Spec.Mode = ModeDeployment
+				d.Spec.Flags = []Param{{Name: FlagDeschedulingInterval, Value: "-5m"}}
+			},
+			errors: []string{"FieldValueInvalid spec.flags[0].value"},
+		},
+		{
+			name: "descheduling-interval must be a duration",
+			change: func(d *Descheduler) {
+				d.Spec.Mode = ModeDeployment
+				d.Spec.Flags = []Param{{Name: FlagDeschedulingInterval, Value: "5"}}
+			},
+			errors: []string{"FieldValueInvalid spec.flags[0].value"},
+		},
+		{
+			name: "descheduling-interval forbidden in CronJob mode",
+			change: func(d *Descheduler) {
+				d.Spec.Flags = []Param{{Name: FlagDeschedulingInterval, Value: "5m"}}
+			},
+			errors: []string{"FieldValueForbidden spec.flags[0].name"},
+		},
+		{
+			name: "descheduling-interval forbidden in Job mode",
+			change: func(d *Descheduler) {
+				d.Spec.Mode = ModeJob
+				d.Spec.Flags = []Param{{Name: "node-selector", Value: "pool=a"}, {Name: FlagDeschedulingInterval, Value: "5m"}}
+			},
+			errors: []string{"FieldValueForbidden spec.flags[1].name"},
+		},
+		{
+			name:   "unknown flag",
+			change: func(d *Descheduler) { d.Spec.Flags = []Param{{Name: "kubeconfig", Value: "/root/.kube/config"}} },
+			errors: []string{"FieldValueNotSupported spec.flags[0].name"},
+		},
+		{
+			name:   "dry-run flag",
+			change: func(d *Descheduler) { d.Spec.Flags = []Param{{Name: FlagDryRun}} },
+		},
+		{
+			name: "dry-run flag conflicts with spec.dryRun",
+			change: func(d *Descheduler) {
+				d.Spec.DryRun = true
+				d.Spec.Flags = []Param{{Name: FlagDryRun}}
+			},
+			errors: []string{"FieldValueForbidden spec.flags[0].name"},
+		},
+		{
+			name:   "spec.dryRun",
+			change: func(d *Descheduler) { d.Spec.DryRun = true },
+		},
+		{
+			name:   "at least one strategy",
+			change: func(d *Descheduler) { d.Spec.Strategies = DeschedulerStrategies{} },
+			errors: []string{"FieldValueRequired spec.strategies"},
+		},
+		{
+			name: "threshold above its target threshold",
+			change: func(d *Descheduler) {
+				d.Spec.Strategies.LowNodeUtilization.Thresholds.CPU = 80
+			},
+			errors: []string{"FieldValueInvalid spec.strategies.lowNodeUtilization.thresholds.cpu"},
+		},
+		{
+			name: "threshold above 100",
+			change: func(d *Descheduler) {
+				d.Spec.Strategies.LowNodeUtilization.TargetThresholds.Memory = 120
+			},
+			errors: []string{"FieldValueInvalid spec.strategies.lowNodeUtilization.targetThresholds.memory"},
+		},
+		{
+			name: "namespaces include",
+			change: func(d *Descheduler) {
+				d.Spec.Strategies.RemoveDuplicates.Namespaces = &Namespaces{Include: []string{"default", "apps"}}
+			},
+		},
+		{
+			name: "namespaces include and exclude are exclusive",
+			change: func(d *Descheduler) {
+				d.Spec.Strategies.RemoveDuplicates.Namespaces = &Namespaces{Include: []string{"default"}, Exclude: []string{"kube-system"}}
+			},
+			errors: []string{"FieldValueForbidden spec.strategies.removeDuplicates.namespaces.exclude"},
+		},
+		{
+			name: "namespaces must be DNS labels",
+			change: func(d *Descheduler) {
+				d.Spec.Strategies.RemoveDuplicates.Namespaces = &Namespaces{Exclude: []string{"kube-system", "Kube_Public"}}
+			},
+			errors: []string{"FieldValueInvalid spec.strategies.removeDuplicates.namespaces.exclude[1]"},
+		},
+		{
+			name: "unsupported pod phase",
+			change: func(d *Descheduler) {
+				d.Spec.Strategies.PodLifeTime = &PodLifeTimeStrategy{MaxPodLifeTimeSeconds: 60, PodStatusPhases: []corev1.PodPhase{corev1.PodSucceeded}}
+			},
+			errors: []string{"FieldValueNotSupported spec.strategies.podLifeTime.podStatusPhases[0]"},
+		},
+		{
+			name: "priority threshold value and class are exclusive",
+			change: func(d *Descheduler) {
+				d.Spec.PriorityThreshold = PriorityThreshold{ThresholdPriority: int32Ptr(1000), ThresholdPriorityClassName: "critical"}
+			},
+			errors: []string{"FieldValueForbidden spec.thresholdPriorityClassName"},
+		},
+		{
+			name:   "log verbosity above 10",
+			change: func(d *Descheduler) { d.Spec.LogVerbosity = int32Ptr(11) },
+			errors: []string{"FieldValueInvalid spec.logVerbosity"},
+		},
+		{
+			name: "run retention bounds",
+			change: func(d *Descheduler) {
+				d.Spec.RunRetention = &RunRetention{MaxCount: int32Ptr(0), MaxAge: &metav1.Duration{}}
+			},
+			errors: []string{"FieldValueInvalid spec.runRetention.maxAge", "FieldValueInvalid spec.runRetention.maxCount"},
+		},
+		{
+			name:   "run history limit of 0",
+			change: func(d *Descheduler) { d.Spec.RunHistoryLimit = int32Ptr(0) },
+			errors: []string{"FieldValueInvalid spec.runHistoryLimit"},
+		},
+		{
+			name:   "run history limit above 100",
+			change: func(d *Descheduler) { d.Spec.RunHistoryLimit = int32Ptr(101) },
+			errors: []string{"FieldValueInvalid spec.runHistoryLimit"},
+		},
+		{
+			name: "profiles need v1alpha2 and exclude strategies",
+			change: func(d *Descheduler) {
+				d.Spec.Profiles = []DeschedulerProfile{{Name: "default", Strategies: d.Spec.Strategies}}
+			},
+			errors: []string{"FieldValueForbidden spec.profiles", "FieldValueForbidden spec.strategies"},
+		},
+		{
+			name: "profile names are unique",
+			change: func(d *Descheduler) {
+				d.Spec.PolicyVersion = PolicyVersionV1alpha2
+				d.Spec.Profiles = []DeschedulerProfile{
+					{Name: "balance", Strategies: d.Spec.Strategies},
+					{Name: "balance", Strategies: d.Spec.Strategies},
+				}
+				d.Spec.Strategies = DeschedulerStrategies{}
+			},
+			errors: []string{"FieldValueDuplicate spec.profiles[1].name"},
+		},
+		{
+			name: "no strategy priority threshold in v1alpha2",
+			change: func(d *Descheduler) {
+				d.Spec.PolicyVersion = PolicyVersionV1alpha2
+				d.Spec.Strategies.RemoveDuplicates.ThresholdPriority = int32Ptr(1000)
+			},
+			errors: []string{"FieldValueForbidden spec.strategies.removeDuplicates"},
+		},
+		{
+			name: "policy replaces the strategies",
+			change: func(d *Descheduler) {
+				d.Spec.Policy = &PolicySource{ConfigMapRef: &PolicyConfigMapReference{Name: "policy", Key: "policy.yaml"}}
+			},
+			errors: []string{"FieldValueForbidden spec.strategies"},
+		},
+		{
+			name: "policy from a ConfigMap",
+			change: func(d *Descheduler) {
+				d.Spec.Strategies = DeschedulerStrategies{}
+				d.Spec.Policy = &PolicySource{ConfigMapRef: &PolicyConfigMapReference{Name: "policy", Key: "policy.yaml"}}
+			},
+		},
+		{
+			name: "policy raw and configMapRef are exclusive",
+			change: func(d *Descheduler) {
+				d.Spec.Strategies = DeschedulerStrategies{}
+				d.Spec.Policy = &PolicySource{
+					Raw:          &runtime.RawExtension{Raw: []byte(`{"kind":"DeschedulerPolicy"}`)},
+					ConfigMapRef: &PolicyConfigMapReference{Name: "policy", Key: "policy.yaml"},
+				}
+			},
+			errors: []string{"FieldValueForbidden spec.policy.configMapRef"},
+		},
+		{
+			name: "policy ConfigMap can't be the generated one",
+			change: func(d *Descheduler) {
+				d.Spec.Strategies = DeschedulerStrategies{}
+				d.Spec.Policy = &PolicySource{ConfigMapRef: &PolicyConfigMapReference{Name: d.Name, Key: "policy.yaml"}}
+			},
+			errors: []string{"FieldValueInvalid spec.policy.configMapRef.name"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			d := validDescheduler()
+			test.change(d)
+			var errors []string
+			for _, err := range d.ValidateFields() {
+				errors = append(errors, string(err.Type)+" "+err.Field)
+			}
+			sort.Strings(errors)
+			if len(errors) == 0 && len(test.errors) == 0 {
+				return
+			}
+			if !reflect.DeepEqual(errors, test.errors) {
+				t.Errorf("expected errors %v, got %v", test.errors, errors)
+			}
+		})
+	}
+}
+
+func TestDefaultedDeschedulerIsValid(t *testing.T) {
+	d := &Descheduler{Spec: DeschedulerSpec{Strategies: DeschedulerStrategies{LowNodeUtilization: &LowNodeUtilizationStrategy{}}}}
+	d.Default()
+	if err := d.Validate(); err != nil {
+		t.Errorf("defaulted Descheduler is invalid: %v", err)
+	}
+}
+
+func TestValidateReportsEveryError(t *testing.T) {
+	d := validDescheduler()
+	d.Spec.Schedule = ""
+	d.Spec.Flags = []Param{{Name: "unknown"}}
+	errs := d.ValidateFields()
+	if len(errs) != 2 {
+		t.Fatalf("expected 2 errors, got %v", errs)
+	}
+	if errs[0].Type != field.ErrorTypeRequired || errs[1].Type != field.ErrorTypeNotSupported {
+		t.Errorf("unexpected errors %v", errs)
+	}
+}
@@ -0,0 +1,69 @@
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DeschedulerLabel is set on the DeschedulerRuns of a Descheduler with the name of the Descheduler
+const DeschedulerLabel = "descheduler.axway.com/descheduler"
+
+// DeschedulerRunSpec describes what a descheduler Job ran
+// +k8s:openapi-gen=true
+type DeschedulerRunSpec struct {
+	// DeschedulerName is the name of the Descheduler the Job was created for
+	DeschedulerName string `json:"deschedulerName"`
+	// JobName is the name of the Job
+	JobName string `json:"jobName"`
+	// PolicyHash is the sha256 of the policy.yaml the Job ran with
+	PolicyHash string `json:"policyHash,omitempty"`
+	// Image is the descheduler image the Job ran
+	Image string `json:"image"`
+	// Flags are the arguments descheduler ran with
+	Flags []string `json:"flags,omitempty"`
+	// DryRun is true when descheduler ran with --dry-run, Evictions are then the pods it would have evicted
+	DryRun bool `json:"dryRun,omitempty"`
+}
+
+// DeschedulerRunStatus is the outcome of a descheduler Job
+// +k8s:openapi-gen=true
+type DeschedulerRunStatus struct {
+	// PodName is the name of the last pod of the Job
+	PodName string `json:"podName,omitempty"`
+	// StartTime is the time the Job started
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+	// CompletionTime is the time the Job succeeded or failed
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+	// Result is Active while the Job runs, then Succeeded or Failed, or Superseded when a spec change replaced it
+	Result DeschedulerRunResult `json:"result,omitempty"`
+	// Evictions are the pods evicted by the Job, read from its logs once it finished
+	Evictions *EvictionSummary `json:"evictions,omitempty"`
+	// MetricsRecorded is true once the evictions were added to the operator metrics, so they are counted once
+	MetricsRecorded bool `json:"metricsRecorded,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// DeschedulerRun records one run of descheduler, it is created by the operator for every Job
+// of the CronJob of a Descheduler
+// +k8s:openapi-gen=true
+// +kubebuilder:subresource:status
+type DeschedulerRun struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   DeschedulerRunSpec   `json:"spec,omitempty"`
+	Status DeschedulerRunStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// DeschedulerRunList contains a list of DeschedulerRun
+type DeschedulerRunList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []DeschedulerRun `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&DeschedulerRun{}, &DeschedulerRunList{})
+}
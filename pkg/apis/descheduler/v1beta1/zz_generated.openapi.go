@@ -0,0 +1,1783 @@
+// +build !ignore_autogenerated
+
+// This file was autogenerated by openapi-gen. Do not edit it manually!
+
+package v1beta1
+
+import (
+	spec "github.com/go-openapi/spec"
+	common "k8s.io/kube-openapi/pkg/common"
+)
+
+func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenAPIDefinition {
+	return map[string]common.OpenAPIDefinition{
+		"github.com/skckadiyala/descheduler-operator/pkg/apis/descheduler/v1beta1.Descheduler":                                         schema_pkg_apis_descheduler_v1beta1_Descheduler(ref),
+		"github.com/skckadiyala/descheduler-operator/pkg/apis/descheduler/v1beta1.DeschedulerCondition":                                schema_pkg_apis_descheduler_v1beta1_DeschedulerCondition(ref),
+		"github.com/skckadiyala/descheduler-operator/pkg/apis/descheduler/v1beta1.DeschedulerProfile":                                  schema_pkg_apis_descheduler_v1beta1_DeschedulerProfile(ref),
+		"github.com/skckadiyala/descheduler-operator/pkg/apis/descheduler/v1beta1.DeschedulerRun":                                      schema_pkg_apis_descheduler_v1beta1_DeschedulerRun(ref),
+		"github.com/skckadiyala/descheduler-operator/pkg/apis/descheduler/v1beta1.DeschedulerRunSpec":                                  schema_pkg_apis_descheduler_v1beta1_DeschedulerRunSpec(ref),
+		"github.com/skckadiyala/descheduler-operator/pkg/apis/descheduler/v1beta1.DeschedulerRunStatus":                                schema_pkg_apis_descheduler_v1beta1_DeschedulerRunStatus(ref),
+		"github.com/skckadiyala/descheduler-operator/pkg/apis/descheduler/v1beta1.DeschedulerSpec":                                     schema_pkg_apis_descheduler_v1beta1_DeschedulerSpec(ref),
+		"github.com/skckadiyala/descheduler-operator/pkg/apis/descheduler/v1beta1.DeschedulerStatus":                                   schema_pkg_apis_descheduler_v1beta1_DeschedulerStatus(ref),
+		"github.com/skckadiyala/descheduler-operator/pkg/apis/descheduler/v1beta1.DeschedulerStrategies":                               schema_pkg_apis_descheduler_v1beta1_DeschedulerStrategies(ref),
+		"github.com/skckadiyala/descheduler-operator/pkg/apis/descheduler/v1beta1.DryRunReport":                                        schema_pkg_apis_descheduler_v1beta1_DryRunReport(ref),
+		"github.com/skckadiyala/descheduler-operator/pkg/apis/descheduler/v1beta1.EvictedPod":                                          schema_pkg_apis_descheduler_v1beta1_EvictedPod(ref),
+		"github.com/skckadiyala/descheduler-operator/pkg/apis/descheduler/v1beta1.EvictionSummary":                                     schema_pkg_apis_descheduler_v1beta1_EvictionSummary(ref),
+		"github.com/skckadiyala/descheduler-operator/pkg/apis/descheduler/v1beta1.EvictorOptions":                                      schema_pkg_apis_descheduler_v1beta1_EvictorOptions(ref),
+		"github.com/skckadiyala/descheduler-operator/pkg/apis/descheduler/v1beta1.HighNodeUtilizationStrategy":                         schema_pkg_apis_descheduler_v1beta1_HighNodeUtilizationStrategy(ref),
+		"github.com/skckadiyala/descheduler-operator/pkg/apis/descheduler/v1beta1.ImageFallback":                                       schema_pkg_apis_descheduler_v1beta1_ImageFallback(ref),
+		"github.com/skckadiyala/descheduler-operator/pkg/apis/descheduler/v1beta1.LowNodeUtilizationStrategy":                          schema_pkg_apis_descheduler_v1beta1_LowNodeUtilizationStrategy(ref),
+		"github.com/skckadiyala/descheduler-operator/pkg/apis/descheduler/v1beta1.ManualRun":                                           schema_pkg_apis_descheduler_v1beta1_ManualRun(ref),
+		"github.com/skckadiyala/descheduler-operator/pkg/apis/descheduler/v1beta1.Namespaces":                                          schema_pkg_apis_descheduler_v1beta1_Namespaces(ref),
+		"github.com/skckadiyala/descheduler-operator/pkg/apis/descheduler/v1beta1.Param":                                               schema_pkg_apis_descheduler_v1beta1_Param(ref),
+		"github.com/skckadiyala/descheduler-operator/pkg/apis/descheduler/v1beta1.PodLifeTimeStrategy":                                 schema_pkg_apis_descheduler_v1beta1_PodLifeTimeStrategy(ref),
+		"github.com/skckadiyala/descheduler-operator/pkg/apis/descheduler/v1beta1.PolicyConfigMapReference":                            schema_pkg_apis_descheduler_v1beta1_PolicyConfigMapReference(ref),
+		"github.com/skckadiyala/descheduler-operator/pkg/apis/descheduler/v1beta1.PolicySource":                                        schema_pkg_apis_descheduler_v1beta1_PolicySource(ref),
+		"github.com/skckadiyala/descheduler-operator/pkg/apis/descheduler/v1beta1.PriorityThreshold":                                   schema_pkg_apis_descheduler_v1beta1_PriorityThreshold(ref),
+		"github.com/skckadiyala/descheduler-operator/pkg/apis/descheduler/v1beta1.RemoveDuplicatesStrategy":                            schema_pkg_apis_descheduler_v1beta1_RemoveDuplicatesStrategy(ref),
+		"github.com/skckadiyala/descheduler-operator/pkg/apis/descheduler/v1beta1.RemoveFailedPodsStrategy":                            schema_pkg_apis_descheduler_v1beta1_RemoveFailedPodsStrategy(ref),
+		"github.com/skckadiyala/descheduler-operator/pkg/apis/descheduler/v1beta1.RemovePodsHavingTooManyRestartsStrategy":             schema_pkg_apis_descheduler_v1beta1_RemovePodsHavingTooManyRestartsStrategy(ref),
+		"github.com/skckadiyala/descheduler-operator/pkg/apis/descheduler/v1beta1.RemovePodsViolatingInterPodAntiAffinityStrategy":     schema_pkg_apis_descheduler_v1beta1_RemovePodsViolatingInterPodAntiAffinityStrategy(ref),
+		"github.com/skckadiyala/descheduler-operator/pkg/apis/descheduler/v1beta1.RemovePodsViolatingNodeAffinityStrategy":             schema_pkg_apis_descheduler_v1beta1_RemovePodsViolatingNodeAffinityStrategy(ref),
+		"github.com/skckadiyala/descheduler-operator/pkg/apis/descheduler/v1beta1.RemovePodsViolatingNodeTaintsStrategy":               schema_pkg_apis_descheduler_v1beta1_RemovePodsViolatingNodeTaintsStrategy(ref),
+		"github.com/skckadiyala/descheduler-operator/pkg/apis/descheduler/v1beta1.RemovePodsViolatingTopologySpreadConstraintStrategy": schema_pkg_apis_descheduler_v1beta1_RemovePodsViolatingTopologySpreadConstraintStrategy(ref),
+		"github.com/skckadiyala/descheduler-operator/pkg/apis/descheduler/v1beta1.ResourceThresholds":                                  schema_pkg_apis_descheduler_v1beta1_ResourceThresholds(ref),
+		"github.com/skckadiyala/descheduler-operator/pkg/apis/descheduler/v1beta1.RunRetention":                                        schema_pkg_apis_descheduler_v1beta1_RunRetention(ref),
+		"github.com/skckadiyala/descheduler-operator/pkg/apis/descheduler/v1beta1.RunSummary":                                          schema_pkg_apis_descheduler_v1beta1_RunSummary(ref),
+	}
+}
+
+func schema_pkg_apis_descheduler_v1beta1_Descheduler(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "Descheduler is the Schema for the deschedulers API",
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"),
+						},
+					},
+					"spec": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("github.com/skckadiyala/descheduler-operator/pkg/apis/descheduler/v1beta1.DeschedulerSpec"),
+						},
+					},
+					"status": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("github.com/skckadiyala/descheduler-operator/pkg/apis/descheduler/v1beta1.DeschedulerStatus"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/skckadiyala/descheduler-operator/pkg/apis/descheduler/v1beta1.DeschedulerSpec", "github.com/skckadiyala/descheduler-operator/pkg/apis/descheduler/v1beta1.DeschedulerStatus", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"},
+	}
+}
+
+func schema_pkg_apis_descheduler_v1beta1_DeschedulerCondition(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "DeschedulerCondition describes the state of a Descheduler at a certain point",
+				Properties: map[string]spec.Schema{
+					"type": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Type of the condition",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"status": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Status of the condition, one of True, False, Unknown",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"observedGeneration": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ObservedGeneration is the generation of the Descheduler the condition was set for",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"lastTransitionTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "LastTransitionTime is the last time the condition changed from one status to another",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"reason": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Reason is a CamelCase reason for the last transition",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"message": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Message is a human readable message about the last transition",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"type", "status"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
+	}
+}
+
+func schema_pkg_apis_descheduler_v1beta1_DeschedulerProfile(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "DeschedulerProfile is a profile of a v1alpha2 policy, its strategies are rendered as plugins with their args",
+				Properties: map[string]spec.Schema{
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name of the profile, unique in the descheduler",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"strategies": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Strategies enabled in the profile, a strategy left unset is disabled",
+							Ref:         ref("github.com/skckadiyala/descheduler-operator/pkg/apis/descheduler/v1beta1.DeschedulerStrategies"),
+						},
+					},
+					"thresholdPriority": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ThresholdPriority is the priority from which pods are not evicted, it can't be set with thresholdPriorityClassName",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"thresholdPriorityClassName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ThresholdPriorityClassName is the PriorityClass whose value is the threshold priority",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"name", "strategies"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/skckadiyala/descheduler-operator/pkg/apis/descheduler/v1beta1.DeschedulerStrategies"},
+	}
+}
+
+func schema_pkg_apis_descheduler_v1beta1_DeschedulerRun(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "DeschedulerRun records one run of descheduler, it is created by the operator for every Job of the CronJob of a Descheduler",
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"),
+						},
+					},
+					"spec": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("github.com/skckadiyala/descheduler-operator/pkg/apis/descheduler/v1beta1.DeschedulerRunSpec"),
+						},
+					},
+					"status": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("github.com/skckadiyala/descheduler-operator/pkg/apis/descheduler/v1beta1.DeschedulerRunStatus"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/skckadiyala/descheduler-operator/pkg/apis/descheduler/v1beta1.DeschedulerRunSpec", "github.com/skckadiyala/descheduler-operator/pkg/apis/descheduler/v1beta1.DeschedulerRunStatus", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"},
+	}
+}
+
+func schema_pkg_apis_descheduler_v1beta1_DeschedulerRunSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "DeschedulerRunSpec describes what a descheduler Job ran",
+				Properties: map[string]spec.Schema{
+					"deschedulerName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "DeschedulerName is the name of the Descheduler the Job was created for",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"jobName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "JobName is the name of the Job",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"policyHash": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PolicyHash is the sha256 of the policy.yaml the Job ran with",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"image": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Image is the descheduler image the Job ran",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"flags": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Flags are the arguments descheduler ran with",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+					"dryRun": {
+						SchemaProps: spec.SchemaProps{
+							Description: "DryRun is true when descheduler ran with --dry-run, Evictions are then the pods it would have evicted",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"deschedulerName", "jobName", "image"},
+			},
+		},
+		Dependencies: []string{},
+	}
+}
+
+func schema_pkg_apis_descheduler_v1beta1_DeschedulerRunStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "DeschedulerRunStatus is the outcome of a descheduler Job",
+				Properties: map[string]spec.Schema{
+					"podName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PodName is the name of the last pod of the Job",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"startTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "StartTime is the time the Job started",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"completionTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CompletionTime is the time the Job succeeded or failed",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"result": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Result is Active while the Job runs, then Succeeded or Failed, or Superseded when a spec change replaced it",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"evictions": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Evictions are the pods evicted by the Job, read from its logs once it finished",
+							Ref:         ref("github.com/skckadiyala/descheduler-operator/pkg/apis/descheduler/v1beta1.EvictionSummary"),
+						},
+					},
+					"metricsRecorded": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MetricsRecorded is true once the evictions were added to the operator metrics, so they are counted once",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/skckadiyala/descheduler-operator/pkg/apis/descheduler/v1beta1.EvictionSummary", "k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
+	}
+}
+
+func schema_pkg_apis_descheduler_v1beta1_DeschedulerSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "DeschedulerSpec defines the desired state of Descheduler",
+				Properties: map[string]spec.Schema{
+					"strategies": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Strategies that should be enabled in descheduler, a strategy left unset is disabled",
+							Ref:         ref("github.com/skckadiyala/descheduler-operator/pkg/apis/descheduler/v1beta1.DeschedulerStrategies"),
+						},
+					},
+					"mode": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Mode is how descheduler runs, CronJob by default",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"schedule": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Schedule on which cronjob should run, only used in CronJob mode",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"flags": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Flags for descheduler",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("github.com/skckadiyala/descheduler-operator/pkg/apis/descheduler/v1beta1.Param"),
+									},
+								},
+							},
+						},
+					},
+					"image": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Image of the descheduler being managed, this includes the version",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"logVerbosity": {
+						SchemaProps: spec.SchemaProps{
+							Description: "LogVerbosity is the klog verbosity descheduler runs with, evictions are logged from 5 on",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"runRetention": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RunRetention bounds the DeschedulerRun records kept for the descheduler runs",
+							Ref:         ref("github.com/skckadiyala/descheduler-operator/pkg/apis/descheduler/v1beta1.RunRetention"),
+						},
+					},
+					"runHistoryLimit": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RunHistoryLimit is the number of descheduler runs kept in status.runs",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"imagePullFailureThreshold": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ImagePullFailureThreshold is the number of consecutive runs failing to pull the image before the operator runs the default image instead, until the image is changed. 0 disables the fallback.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"suspend": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Suspend stops the CronJob from starting new runs and manual runs from being triggered",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"terminateActiveRuns": {
+						SchemaProps: spec.SchemaProps{
+							Description: "TerminateActiveRuns deletes the runs still active when the descheduler is suspended",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"dryRun": {
+						SchemaProps: spec.SchemaProps{
+							Description: "DryRun runs descheduler with --dry-run, no pod is evicted and the pods it would evict are reported in status.dryRunReport and in the <name>-dry-run ConfigMap",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"adoptExisting": {
+						SchemaProps: spec.SchemaProps{
+							Description: "AdoptExisting is what the operator does with a ConfigMap, CronJob or Deployment named after the descheduler that it didn't create, Refuse by default",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"thresholdPriority": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ThresholdPriority is the priority from which pods are not evicted, it can't be set with thresholdPriorityClassName",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"thresholdPriorityClassName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ThresholdPriorityClassName is the PriorityClass whose value is the threshold priority",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"evictLocalStoragePods": {
+						SchemaProps: spec.SchemaProps{
+							Description: "EvictLocalStoragePods allows evicting pods using local storage",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"evictSystemCriticalPods": {
+						SchemaProps: spec.SchemaProps{
+							Description: "EvictSystemCriticalPods allows evicting system critical pods, and pods of any priority",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"ignorePvcPods": {
+						SchemaProps: spec.SchemaProps{
+							Description: "IgnorePvcPods keeps pods with PersistentVolumeClaims from being evicted",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"maxNoOfPodsToEvictPerNode": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MaxNoOfPodsToEvictPerNode is the maximum number of pods evicted from a node in a run, unlimited when unset or 0",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"maxNoOfPodsToEvictPerNamespace": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MaxNoOfPodsToEvictPerNamespace is the maximum number of pods evicted from a namespace in a run, unlimited when unset or 0",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"policyVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PolicyVersion is the API version of the policy rendered for descheduler. When unset it is v1alpha1, or v1alpha2 when the descheduler release of the image no longer reads v1alpha1. A v1alpha2 policy runs the strategies as the plugins of a profile named default unless profiles are set.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"profiles": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Profiles are the named sets of strategies of a v1alpha2 policy, they replace strategies",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("github.com/skckadiyala/descheduler-operator/pkg/apis/descheduler/v1beta1.DeschedulerProfile"),
+									},
+								},
+							},
+						},
+					},
+					"policy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Policy is a complete descheduler policy used as is instead of strategies and profiles",
+							Ref:         ref("github.com/skckadiyala/descheduler-operator/pkg/apis/descheduler/v1beta1.PolicySource"),
+						},
+					},
+				},
+				Required: []string{"strategies"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/skckadiyala/descheduler-operator/pkg/apis/descheduler/v1beta1.DeschedulerProfile", "github.com/skckadiyala/descheduler-operator/pkg/apis/descheduler/v1beta1.DeschedulerStrategies", "github.com/skckadiyala/descheduler-operator/pkg/apis/descheduler/v1beta1.Param", "github.com/skckadiyala/descheduler-operator/pkg/apis/descheduler/v1beta1.PolicySource", "github.com/skckadiyala/descheduler-operator/pkg/apis/descheduler/v1beta1.RunRetention"},
+	}
+}
+
+func schema_pkg_apis_descheduler_v1beta1_DeschedulerStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "DeschedulerStatus defines the observed state of Descheduler",
+				Properties: map[string]spec.Schema{
+					"phase": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Phase is kept for v1alpha1 clients, use Conditions instead",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"observedGeneration": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ObservedGeneration is the generation of the Descheduler last reconciled by the operator",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"conditions": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-patch-merge-key": "type",
+								"x-kubernetes-patch-strategy":  "merge",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "Conditions describe why the descheduler is or is not healthy",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("github.com/skckadiyala/descheduler-operator/pkg/apis/descheduler/v1beta1.DeschedulerCondition"),
+									},
+								},
+							},
+						},
+					},
+					"lastScheduleTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "LastScheduleTime is the last time the CronJob of the descheduler started a Job",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"lastSuccessfulTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "LastSuccessfulTime is the completion time of the last descheduler Job that succeeded",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"runs": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Runs are the last descheduler Jobs, most recent first",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("github.com/skckadiyala/descheduler-operator/pkg/apis/descheduler/v1beta1.RunSummary"),
+									},
+								},
+							},
+						},
+					},
+					"lastEvictions": {
+						SchemaProps: spec.SchemaProps{
+							Description: "LastEvictions summarizes the pods evicted by the last finished descheduler Job, read from its logs",
+							Ref:         ref("github.com/skckadiyala/descheduler-operator/pkg/apis/descheduler/v1beta1.EvictionSummary"),
+						},
+					},
+					"imageFallback": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ImageFallback is set while the CronJob runs the default image because spec.image failed to pull",
+							Ref:         ref("github.com/skckadiyala/descheduler-operator/pkg/apis/descheduler/v1beta1.ImageFallback"),
+						},
+					},
+					"lastManualRun": {
+						SchemaProps: spec.SchemaProps{
+							Description: "LastManualRun is the last run triggered with the RunNowAnnotation",
+							Ref:         ref("github.com/skckadiyala/descheduler-operator/pkg/apis/descheduler/v1beta1.ManualRun"),
+						},
+					},
+					"dryRunReport": {
+						SchemaProps: spec.SchemaProps{
+							Description: "DryRunReport lists the pods the last finished dry run would have evicted",
+							Ref:         ref("github.com/skckadiyala/descheduler-operator/pkg/apis/descheduler/v1beta1.DryRunReport"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/skckadiyala/descheduler-operator/pkg/apis/descheduler/v1beta1.DeschedulerCondition", "github.com/skckadiyala/descheduler-operator/pkg/apis/descheduler/v1beta1.DryRunReport", "github.com/skckadiyala/descheduler-operator/pkg/apis/descheduler/v1beta1.EvictionSummary", "github.com/skckadiyala/descheduler-operator/pkg/apis/descheduler/v1beta1.ImageFallback", "github.com/skckadiyala/descheduler-operator/pkg/apis/descheduler/v1beta1.ManualRun", "github.com/skckadiyala/descheduler-operator/pkg/apis/descheduler/v1beta1.RunSummary", "k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
+	}
+}
+
+func schema_pkg_apis_descheduler_v1beta1_DeschedulerStrategies(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "DeschedulerStrategies holds the typed parameters of every strategy supported by descheduler",
+				Properties: map[string]spec.Schema{
+					"removeDuplicates": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RemoveDuplicates evicts pods of the same owner running on the same node",
+							Ref:         ref("github.com/skckadiyala/descheduler-operator/pkg/apis/descheduler/v1beta1.RemoveDuplicatesStrategy"),
+						},
+					},
+					"lowNodeUtilization": {
+						SchemaProps: spec.SchemaProps{
+							Description: "LowNodeUtilization evicts pods from overutilized nodes so they can land on underutilized ones",
+							Ref:         ref("github.com/skckadiyala/descheduler-operator/pkg/apis/descheduler/v1beta1.LowNodeUtilizationStrategy"),
+						},
+					},
+					"removePodsViolatingInterPodAntiAffinity": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RemovePodsViolatingInterPodAntiAffinity evicts pods violating inter-pod anti-affinity rules",
+							Ref:         ref("github.com/skckadiyala/descheduler-operator/pkg/apis/descheduler/v1beta1.RemovePodsViolatingInterPodAntiAffinityStrategy"),
+						},
+					},
+					"removePodsViolatingNodeAffinity": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RemovePodsViolatingNodeAffinity evicts pods which no longer satisfy their node affinity",
+							Ref:         ref("github.com/skckadiyala/descheduler-operator/pkg/apis/descheduler/v1beta1.RemovePodsViolatingNodeAffinityStrategy"),
+						},
+					},
+					"removePodsViolatingNodeTaints": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RemovePodsViolatingNodeTaints evicts pods which don't tolerate the NoSchedule taints of their node",
+							Ref:         ref("github.com/skckadiyala/descheduler-operator/pkg/apis/descheduler/v1beta1.RemovePodsViolatingNodeTaintsStrategy"),
+						},
+					},
+					"removePodsHavingTooManyRestarts": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RemovePodsHavingTooManyRestarts evicts pods whose containers restarted too many times",
+							Ref:         ref("github.com/skckadiyala/descheduler-operator/pkg/apis/descheduler/v1beta1.RemovePodsHavingTooManyRestartsStrategy"),
+						},
+					},
+					"podLifeTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PodLifeTime evicts pods older than a maximum lifetime",
+							Ref:         ref("github.com/skckadiyala/descheduler-operator/pkg/apis/descheduler/v1beta1.PodLifeTimeStrategy"),
+						},
+					},
+					"removePodsViolatingTopologySpreadConstraint": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RemovePodsViolatingTopologySpreadConstraint evicts pods so their topology spread constraints are satisfied",
+							Ref:         ref("github.com/skckadiyala/descheduler-operator/pkg/apis/descheduler/v1beta1.RemovePodsViolatingTopologySpreadConstraintStrategy"),
+						},
+					},
+					"highNodeUtilization": {
+						SchemaProps: spec.SchemaProps{
+							Description: "HighNodeUtilization evicts pods from underutilized nodes so they can be scaled down",
+							Ref:         ref("github.com/skckadiyala/descheduler-operator/pkg/apis/descheduler/v1beta1.HighNodeUtilizationStrategy"),
+						},
+					},
+					"removeFailedPods": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RemoveFailedPods evicts pods in the Failed phase",
+							Ref:         ref("github.com/skckadiyala/descheduler-operator/pkg/apis/descheduler/v1beta1.RemoveFailedPodsStrategy"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/skckadiyala/descheduler-operator/pkg/apis/descheduler/v1beta1.HighNodeUtilizationStrategy", "github.com/skckadiyala/descheduler-operator/pkg/apis/descheduler/v1beta1.LowNodeUtilizationStrategy", "github.com/skckadiyala/descheduler-operator/pkg/apis/descheduler/v1beta1.PodLifeTimeStrategy", "github.com/skckadiyala/descheduler-operator/pkg/apis/descheduler/v1beta1.RemoveDuplicatesStrategy", "github.com/skckadiyala/descheduler-operator/pkg/apis/descheduler/v1beta1.RemoveFailedPodsStrategy", "github.com/skckadiyala/descheduler-operator/pkg/apis/descheduler/v1beta1.RemovePodsHavingTooManyRestartsStrategy", "github.com/skckadiyala/descheduler-operator/pkg/apis/descheduler/v1beta1.RemovePodsViolatingInterPodAntiAffinityStrategy", "github.com/skckadiyala/descheduler-operator/pkg/apis/descheduler/v1beta1.RemovePodsViolatingNodeAffinityStrategy", "github.com/skckadiyala/descheduler-operator/pkg/apis/descheduler/v1beta1.RemovePodsViolatingNodeTaintsStrategy", "github.com/skckadiyala/descheduler-operator/pkg/apis/descheduler/v1beta1.RemovePodsViolatingTopologySpreadConstraintStrategy"},
+	}
+}
+
+func schema_pkg_apis_descheduler_v1beta1_DryRunReport(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "DryRunReport is the outcome of a descheduler Job run with --dry-run",
+				Properties: map[string]spec.Schema{
+					"policyHash": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PolicyHash is the sha256 of the policy.yaml the Job ran with",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"completionTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CompletionTime is the time the Job finished",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"configMapName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ConfigMapName is the ConfigMap holding the full report, up to 1000 pods",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"evictions": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Evictions are the pods descheduler would have evicted, the first 50 are listed",
+							Ref:         ref("github.com/skckadiyala/descheduler-operator/pkg/apis/descheduler/v1beta1.EvictionSummary"),
+						},
+					},
+				},
+				Required: []string{"configMapName", "evictions"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/skckadiyala/descheduler-operator/pkg/apis/descheduler/v1beta1.EvictionSummary", "k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
+	}
+}
+
+func schema_pkg_apis_descheduler_v1beta1_EvictedPod(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "EvictedPod is a pod evicted by descheduler",
+				Properties: map[string]spec.Schema{
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"namespace": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"node": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"strategy": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+				},
+				Required: []string{"name"},
+			},
+		},
+		Dependencies: []string{},
+	}
+}
+
+func schema_pkg_apis_descheduler_v1beta1_EvictionSummary(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "EvictionSummary counts the pods evicted by a descheduler Job",
+				Properties: map[string]spec.Schema{
+					"jobName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "JobName is the name of the Job the logs were read from",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"total": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Total is the number of evicted pods",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"byStrategy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ByStrategy is the number of evicted pods per strategy, pods are counted as unknown when the descheduler logs don't tell the strategy",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"integer"},
+										Format: "int32",
+									},
+								},
+							},
+						},
+					},
+					"byNamespace": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ByNamespace is the number of evicted pods per namespace",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"integer"},
+										Format: "int32",
+									},
+								},
+							},
+						},
+					},
+					"byNode": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ByNode is the number of evicted pods per node",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"integer"},
+										Format: "int32",
+									},
+								},
+							},
+						},
+					},
+					"evictedPods": {
+						SchemaProps: spec.SchemaProps{
+							Description: "EvictedPods lists the first evicted pods, it is truncated to keep the status small",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("github.com/skckadiyala/descheduler-operator/pkg/apis/descheduler/v1beta1.EvictedPod"),
+									},
+								},
+							},
+						},
+					},
+					"error": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Error tells why the logs of the Job couldn't be read, the evictions are then unknown and not read again",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"jobName", "total"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/skckadiyala/descheduler-operator/pkg/apis/descheduler/v1beta1.EvictedPod"},
+	}
+}
+
+func schema_pkg_apis_descheduler_v1beta1_EvictorOptions(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "EvictorOptions are the options of the descheduler evictor, they apply to every strategy",
+				Properties: map[string]spec.Schema{
+					"evictLocalStoragePods": {
+						SchemaProps: spec.SchemaProps{
+							Description: "EvictLocalStoragePods allows evicting pods using local storage",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"evictSystemCriticalPods": {
+						SchemaProps: spec.SchemaProps{
+							Description: "EvictSystemCriticalPods allows evicting system critical pods, and pods of any priority",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"ignorePvcPods": {
+						SchemaProps: spec.SchemaProps{
+							Description: "IgnorePvcPods keeps pods with PersistentVolumeClaims from being evicted",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"maxNoOfPodsToEvictPerNode": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MaxNoOfPodsToEvictPerNode is the maximum number of pods evicted from a node in a run, unlimited when unset or 0",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"maxNoOfPodsToEvictPerNamespace": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MaxNoOfPodsToEvictPerNamespace is the maximum number of pods evicted from a namespace in a run, unlimited when unset or 0",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{},
+	}
+}
+
+func schema_pkg_apis_descheduler_v1beta1_HighNodeUtilizationStrategy(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "HighNodeUtilizationStrategy configures the HighNodeUtilization strategy",
+				Properties: map[string]spec.Schema{
+					"thresholds": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Thresholds below which a node is considered underutilized and its pods evicted",
+							Ref:         ref("github.com/skckadiyala/descheduler-operator/pkg/apis/descheduler/v1beta1.ResourceThresholds"),
+						},
+					},
+					"numberOfNodes": {
+						SchemaProps: spec.SchemaProps{
+							Description: "NumberOfNodes is the number of underutilized nodes required before the strategy evicts pods",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"thresholdPriority": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ThresholdPriority is the priority from which pods are not evicted, it can't be set with thresholdPriorityClassName",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"thresholdPriorityClassName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ThresholdPriorityClassName is the PriorityClass whose value is the threshold priority",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/skckadiyala/descheduler-operator/pkg/apis/descheduler/v1beta1.ResourceThresholds"},
+	}
+}
+
+func schema_pkg_apis_descheduler_v1beta1_ImageFallback(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ImageFallback tells why the CronJob runs the default image instead of spec.image",
+				Properties: map[string]spec.Schema{
+					"failedImage": {
+						SchemaProps: spec.SchemaProps{
+							Description: "FailedImage is the spec.image that failed to pull, the fallback ends when spec.image changes",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"image": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Image is the image the CronJob runs instead",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"reason": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Reason is why the pod of the last failed run was waiting, e.g. ImagePullBackOff",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"since": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Since is the time the operator switched to the fallback image",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+				},
+				Required: []string{"failedImage", "image", "since"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
+	}
+}
+
+func schema_pkg_apis_descheduler_v1beta1_LowNodeUtilizationStrategy(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "LowNodeUtilizationStrategy configures the LowNodeUtilization strategy",
+				Properties: map[string]spec.Schema{
+					"thresholds": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Thresholds below which a node is considered underutilized",
+							Ref:         ref("github.com/skckadiyala/descheduler-operator/pkg/apis/descheduler/v1beta1.ResourceThresholds"),
+						},
+					},
+					"targetThresholds": {
+						SchemaProps: spec.SchemaProps{
+							Description: "TargetThresholds above which a node is considered overutilized",
+							Ref:         ref("github.com/skckadiyala/descheduler-operator/pkg/apis/descheduler/v1beta1.ResourceThresholds"),
+						},
+					},
+					"numberOfNodes": {
+						SchemaProps: spec.SchemaProps{
+							Description: "NumberOfNodes is the number of underutilized nodes required before the strategy evicts pods",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"thresholdPriority": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ThresholdPriority is the priority from which pods are not evicted, it can't be set with thresholdPriorityClassName",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"thresholdPriorityClassName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ThresholdPriorityClassName is the PriorityClass whose value is the threshold priority",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/skckadiyala/descheduler-operator/pkg/apis/descheduler/v1beta1.ResourceThresholds"},
+	}
+}
+
+func schema_pkg_apis_descheduler_v1beta1_ManualRun(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ManualRun is a run triggered with the RunNowAnnotation",
+				Properties: map[string]spec.Schema{
+					"token": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Token is the value of the annotation that triggered the run",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"jobName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "JobName is the name of the Job created for the run, empty when the run was skipped",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"triggeredAt": {
+						SchemaProps: spec.SchemaProps{
+							Description: "TriggeredAt is the time the Job was created",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"result": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Result is Active while the Job runs, then Succeeded or Failed. It is Skipped when the Job couldn't be created, e.g. while the descheduler is suspended.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"reason": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Reason tells why the run was skipped",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"token", "triggeredAt"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
+	}
+}
+
+func schema_pkg_apis_descheduler_v1beta1_Namespaces(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "Namespaces filters the namespaces of the pods a strategy evicts, either to the included namespaces or to all but the excluded ones",
+				Properties: map[string]spec.Schema{
+					"include": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Include lists the only namespaces pods are evicted from, it can't be set with exclude",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+					"exclude": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Exclude lists the namespaces pods are never evicted from, it can't be set with include",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{},
+	}
+}
+
+func schema_pkg_apis_descheduler_v1beta1_Param(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "Param is a key/value pair representing a descheduler flag",
+				Properties: map[string]spec.Schema{
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"value": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{},
+	}
+}
+
+func schema_pkg_apis_descheduler_v1beta1_PodLifeTimeStrategy(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "PodLifeTimeStrategy configures the PodLifeTime strategy",
+				Properties: map[string]spec.Schema{
+					"maxPodLifeTimeSeconds": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MaxPodLifeTimeSeconds is the age from which pods are evicted",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"podStatusPhases": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PodStatusPhases restricts the strategy to the pods in these phases, Pending or Running",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+					"namespaces": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Namespaces restricts the namespaces the strategy evicts pods from",
+							Ref:         ref("github.com/skckadiyala/descheduler-operator/pkg/apis/descheduler/v1beta1.Namespaces"),
+						},
+					},
+					"thresholdPriority": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ThresholdPriority is the priority from which pods are not evicted, it can't be set with thresholdPriorityClassName",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"thresholdPriorityClassName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ThresholdPriorityClassName is the PriorityClass whose value is the threshold priority",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"maxPodLifeTimeSeconds"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/skckadiyala/descheduler-operator/pkg/apis/descheduler/v1beta1.Namespaces"},
+	}
+}
+
+func schema_pkg_apis_descheduler_v1beta1_PolicyConfigMapReference(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "PolicyConfigMapReference refers to the policy in a key of a ConfigMap",
+				Properties: map[string]spec.Schema{
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name of the ConfigMap",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"key": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Key of the policy in the ConfigMap, policy.yaml by default",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"name"},
+			},
+		},
+		Dependencies: []string{},
+	}
+}
+
+func schema_pkg_apis_descheduler_v1beta1_PolicySource(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "PolicySource is a descheduler policy maintained outside of the typed strategies, exactly one of its fields is set",
+				Properties: map[string]spec.Schema{
+					"raw": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Raw is an embedded DeschedulerPolicy, descheduler/v1alpha1 or descheduler/v1alpha2",
+							Ref:         ref("k8s.io/apimachinery/pkg/runtime.RawExtension"),
+						},
+					},
+					"configMapRef": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ConfigMapRef is a ConfigMap of the namespace of the descheduler holding the policy, the operator rolls its changes out",
+							Ref:         ref("github.com/skckadiyala/descheduler-operator/pkg/apis/descheduler/v1beta1.PolicyConfigMapReference"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/skckadiyala/descheduler-operator/pkg/apis/descheduler/v1beta1.PolicyConfigMapReference", "k8s.io/apimachinery/pkg/runtime.RawExtension"},
+	}
+}
+
+func schema_pkg_apis_descheduler_v1beta1_PriorityThreshold(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "PriorityThreshold is the priority from which pods are not evicted, either a value or the value of a PriorityClass",
+				Properties: map[string]spec.Schema{
+					"thresholdPriority": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ThresholdPriority is the priority from which pods are not evicted, it can't be set with thresholdPriorityClassName",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"thresholdPriorityClassName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ThresholdPriorityClassName is the PriorityClass whose value is the threshold priority",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{},
+	}
+}
+
+func schema_pkg_apis_descheduler_v1beta1_RemoveDuplicatesStrategy(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "RemoveDuplicatesStrategy configures the RemoveDuplicates strategy",
+				Properties: map[string]spec.Schema{
+					"namespaces": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Namespaces restricts the namespaces the strategy evicts pods from",
+							Ref:         ref("github.com/skckadiyala/descheduler-operator/pkg/apis/descheduler/v1beta1.Namespaces"),
+						},
+					},
+					"thresholdPriority": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ThresholdPriority is the priority from which pods are not evicted, it can't be set with thresholdPriorityClassName",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"thresholdPriorityClassName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ThresholdPriorityClassName is the PriorityClass whose value is the threshold priority",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/skckadiyala/descheduler-operator/pkg/apis/descheduler/v1beta1.Namespaces"},
+	}
+}
+
+func schema_pkg_apis_descheduler_v1beta1_RemoveFailedPodsStrategy(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "RemoveFailedPodsStrategy configures the RemoveFailedPods strategy",
+				Properties: map[string]spec.Schema{
+					"reasons": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Reasons restricts the strategy to the pods failed for one of these reasons, of the pod or of a container",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+					"includingInitContainers": {
+						SchemaProps: spec.SchemaProps{
+							Description: "IncludingInitContainers also matches the reasons of the init containers",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"excludeOwnerKinds": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ExcludeOwnerKinds are the kinds of owners whose pods are never evicted, e.g. Job",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+					"minPodLifetimeSeconds": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MinPodLifetimeSeconds is the age below which failed pods are kept",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"namespaces": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Namespaces restricts the namespaces the strategy evicts pods from",
+							Ref:         ref("github.com/skckadiyala/descheduler-operator/pkg/apis/descheduler/v1beta1.Namespaces"),
+						},
+					},
+					"thresholdPriority": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ThresholdPriority is the priority from which pods are not evicted, it can't be set with thresholdPriorityClassName",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"thresholdPriorityClassName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ThresholdPriorityClassName is the PriorityClass whose value is the threshold priority",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/skckadiyala/descheduler-operator/pkg/apis/descheduler/v1beta1.Namespaces"},
+	}
+}
+
+func schema_pkg_apis_descheduler_v1beta1_RemovePodsHavingTooManyRestartsStrategy(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "RemovePodsHavingTooManyRestartsStrategy configures the RemovePodsHavingTooManyRestarts strategy",
+				Properties: map[string]spec.Schema{
+					"podRestartThreshold": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PodRestartThreshold is the number of restarts of the containers of a pod from which it is evicted",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"includingInitContainers": {
+						SchemaProps: spec.SchemaProps{
+							Description: "IncludingInitContainers also counts the restarts of the init containers",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"namespaces": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Namespaces restricts the namespaces the strategy evicts pods from",
+							Ref:         ref("github.com/skckadiyala/descheduler-operator/pkg/apis/descheduler/v1beta1.Namespaces"),
+						},
+					},
+					"thresholdPriority": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ThresholdPriority is the priority from which pods are not evicted, it can't be set with thresholdPriorityClassName",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"thresholdPriorityClassName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ThresholdPriorityClassName is the PriorityClass whose value is the threshold priority",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"podRestartThreshold"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/skckadiyala/descheduler-operator/pkg/apis/descheduler/v1beta1.Namespaces"},
+	}
+}
+
+func schema_pkg_apis_descheduler_v1beta1_RemovePodsViolatingInterPodAntiAffinityStrategy(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "RemovePodsViolatingInterPodAntiAffinityStrategy configures the RemovePodsViolatingInterPodAntiAffinity strategy",
+				Properties: map[string]spec.Schema{
+					"namespaces": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Namespaces restricts the namespaces the strategy evicts pods from",
+							Ref:         ref("github.com/skckadiyala/descheduler-operator/pkg/apis/descheduler/v1beta1.Namespaces"),
+						},
+					},
+					"thresholdPriority": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ThresholdPriority is the priority from which pods are not evicted, it can't be set with thresholdPriorityClassName",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"thresholdPriorityClassName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ThresholdPriorityClassName is the PriorityClass whose value is the threshold priority",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/skckadiyala/descheduler-operator/pkg/apis/descheduler/v1beta1.Namespaces"},
+	}
+}
+
+func schema_pkg_apis_descheduler_v1beta1_RemovePodsViolatingNodeAffinityStrategy(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "RemovePodsViolatingNodeAffinityStrategy configures the RemovePodsViolatingNodeAffinity strategy",
+				Properties: map[string]spec.Schema{
+					"nodeAffinityType": {
+						SchemaProps: spec.SchemaProps{
+							Description: "NodeAffinityType lists the node affinity types to check, defaults to requiredDuringSchedulingIgnoredDuringExecution",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+					"namespaces": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Namespaces restricts the namespaces the strategy evicts pods from",
+							Ref:         ref("github.com/skckadiyala/descheduler-operator/pkg/apis/descheduler/v1beta1.Namespaces"),
+						},
+					},
+					"thresholdPriority": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ThresholdPriority is the priority from which pods are not evicted, it can't be set with thresholdPriorityClassName",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"thresholdPriorityClassName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ThresholdPriorityClassName is the PriorityClass whose value is the threshold priority",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/skckadiyala/descheduler-operator/pkg/apis/descheduler/v1beta1.Namespaces"},
+	}
+}
+
+func schema_pkg_apis_descheduler_v1beta1_RemovePodsViolatingNodeTaintsStrategy(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "RemovePodsViolatingNodeTaintsStrategy configures the RemovePodsViolatingNodeTaints strategy",
+				Properties: map[string]spec.Schema{
+					"excludedTaints": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ExcludedTaints are the taints ignored by the strategy, either a key or a key=value",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+					"includePreferNoSchedule": {
+						SchemaProps: spec.SchemaProps{
+							Description: "IncludePreferNoSchedule also evicts pods which don't tolerate the PreferNoSchedule taints of their node",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"namespaces": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Namespaces restricts the namespaces the strategy evicts pods from",
+							Ref:         ref("github.com/skckadiyala/descheduler-operator/pkg/apis/descheduler/v1beta1.Namespaces"),
+						},
+					},
+					"thresholdPriority": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ThresholdPriority is the priority from which pods are not evicted, it can't be set with thresholdPriorityClassName",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"thresholdPriorityClassName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ThresholdPriorityClassName is the PriorityClass whose value is the threshold priority",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/skckadiyala/descheduler-operator/pkg/apis/descheduler/v1beta1.Namespaces"},
+	}
+}
+
+func schema_pkg_apis_descheduler_v1beta1_RemovePodsViolatingTopologySpreadConstraintStrategy(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "RemovePodsViolatingTopologySpreadConstraintStrategy configures the RemovePodsViolatingTopologySpreadConstraint strategy",
+				Properties: map[string]spec.Schema{
+					"includeSoftConstraints": {
+						SchemaProps: spec.SchemaProps{
+							Description: "IncludeSoftConstraints also balances the constraints with whenUnsatisfiable set to ScheduleAnyway",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"namespaces": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Namespaces restricts the namespaces the strategy evicts pods from",
+							Ref:         ref("github.com/skckadiyala/descheduler-operator/pkg/apis/descheduler/v1beta1.Namespaces"),
+						},
+					},
+					"thresholdPriority": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ThresholdPriority is the priority from which pods are not evicted, it can't be set with thresholdPriorityClassName",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"thresholdPriorityClassName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ThresholdPriorityClassName is the PriorityClass whose value is the threshold priority",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/skckadiyala/descheduler-operator/pkg/apis/descheduler/v1beta1.Namespaces"},
+	}
+}
+
+func schema_pkg_apis_descheduler_v1beta1_ResourceThresholds(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ResourceThresholds are percentages of the node allocatable resources, a zero value is left unset",
+				Properties: map[string]spec.Schema{
+					"cpu": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"integer"},
+							Format: "int32",
+						},
+					},
+					"memory": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"integer"},
+							Format: "int32",
+						},
+					},
+					"pods": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"integer"},
+							Format: "int32",
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{},
+	}
+}
+
+func schema_pkg_apis_descheduler_v1beta1_RunRetention(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "RunRetention bounds the DeschedulerRun records of a Descheduler, records beyond either limit are deleted",
+				Properties: map[string]spec.Schema{
+					"maxCount": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MaxCount is the number of records kept",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"maxAge": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MaxAge is how long a record is kept after its run finished",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Duration"},
+	}
+}
+
+func schema_pkg_apis_descheduler_v1beta1_RunSummary(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "RunSummary describes one descheduler Job created by the CronJob",
+				Properties: map[string]spec.Schema{
+					"jobName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "JobName is the name of the Job",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"podName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PodName is the name of the last pod of the Job",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"startTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "StartTime is the time the Job started",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"completionTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CompletionTime is the time the Job succeeded or failed",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"duration": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Duration is the time the Job took to finish",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
+						},
+					},
+					"result": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Result is Active while the Job runs, then Succeeded or Failed, or Superseded",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"image": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Image is the descheduler image the Job runs",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"failureReason": {
+						SchemaProps: spec.SchemaProps{
+							Description: "FailureReason tells why the Job failed or why its pod can't start, e.g. ImagePullBackOff",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"manual": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Manual is true for the Jobs triggered with the RunNowAnnotation",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"dryRun": {
+						SchemaProps: spec.SchemaProps{
+							Description: "DryRun is true for the Jobs run with --dry-run, they evict no pod",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"jobName", "result"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Duration", "k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
+	}
+}
@@ -0,0 +1,81 @@
+package descheduler
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	deschedulerv1beta1 "github.com/skckadiyala/descheduler-operator/pkg/apis/descheduler/v1beta1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// Reasons of the Conflict condition
+const (
+	ReasonResourceExists = "ResourceExists"
+	ReasonNoConflict     = "NoConflict"
+)
+
+// conflictRequeueDelay is how often a conflict is checked again, deleting the conflicting resource doesn't trigger
+// a reconcile as the Descheduler doesn't own it
+const conflictRequeueDelay = time.Minute
+
+// conflictError is returned when a resource named after the Descheduler exists and can't be claimed
+type conflictError struct {
+	kind  string
+	name  string
+	cause string
+}
+
+func (e *conflictError) Error() string {
+	return fmt.Sprintf("%s %s already exists and %s", e.kind, e.name, e.cause)
+}
+
+// ownedObject is an object the Descheduler can be the controller of
+type ownedObject interface {
+	metav1.Object
+	runtime.Object
+}
+
+// claim makes sure the Descheduler is the controller of an existing object before the operator updates or deletes
+// it. An object without controller is adopted when spec.adoptExisting is Adopt, a conflictError is returned otherwise.
+func (r *ReconcileDescheduler) claim(descheduler *deschedulerv1beta1.Descheduler, object ownedObject, kind string) error {
+	if metav1.IsControlledBy(object, descheduler) {
+		return nil
+	}
+	if owner := metav1.GetControllerOf(object); owner != nil {
+		return &conflictError{kind: kind, name: object.GetName(), cause: fmt.Sprintf("is controlled by %s %s", owner.Kind, owner.Name)}
+	}
+	if descheduler.Spec.AdoptExisting != deschedulerv1beta1.AdoptPolicyAdopt {
+		return &conflictError{kind: kind, name: object.GetName(), cause: "wasn't created by the operator, set spec.adoptExisting to Adopt to manage it"}
+	}
+
+	log.Printf("Adopting %s %s/%s", kind, object.GetNamespace(), object.GetName())
+	if err := controllerutil.SetControllerReference(descheduler, object, r.scheme); err != nil {
+		return fmt.Errorf("error setting owner references %v", err)
+	}
+	if err := r.client.Update(context.TODO(), object); err != nil {
+		log.Printf("Error while adopting %s %v", kind, err)
+		return err
+	}
+	r.recorder.Eventf(descheduler, v1.EventTypeNormal, fmt.Sprintf("%sAdopted", kind), "Adopted %s %s", kind, object.GetName())
+	return nil
+}
+
+// conflict sets the Conflict condition for err and requeues the request to check it again later
+func (r *ReconcileDescheduler) conflict(descheduler *deschedulerv1beta1.Descheduler, oldStatus *deschedulerv1beta1.DeschedulerStatus,
+	err *conflictError) (reconcile.Result, error) {
+	log.Printf("Conflict %v", err)
+	r.recorder.Eventf(descheduler, v1.EventTypeWarning, ReasonResourceExists, "%v", err)
+	setCondition(descheduler, deschedulerv1beta1.ConditionConflict, v1.ConditionTrue, ReasonResourceExists, err.Error())
+	setCondition(descheduler, deschedulerv1beta1.ConditionReady, v1.ConditionFalse, ReasonResourceExists,
+		"a resource of the descheduler already exists, see the Conflict condition")
+	if statusErr := r.updateDeschedulerStatus(descheduler, oldStatus); statusErr != nil {
+		return reconcile.Result{}, statusErr
+	}
+	return reconcile.Result{RequeueAfter: conflictRequeueDelay}, nil
+}
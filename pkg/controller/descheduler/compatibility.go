@@ -0,0 +1,264 @@
+package descheduler
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	deschedulerv1beta1 "github.com/skckadiyala/descheduler-operator/pkg/apis/descheduler/v1beta1"
+)
+
+// Reasons of the ImageCompatible condition
+const (
+	ReasonImageCompatible     = "Compatible"
+	ReasonUnsupportedSettings = "UnsupportedSettings"
+	ReasonUnknownImageVersion = "UnknownImageVersion"
+)
+
+// releaseVersion is the version of a descheduler release
+type releaseVersion struct {
+	major, minor, patch int
+}
+
+func (v releaseVersion) String() string {
+	return fmt.Sprintf("v%d.%d.%d", v.major, v.minor, v.patch)
+}
+
+func (v releaseVersion) less(other releaseVersion) bool {
+	if v.major != other.major {
+		return v.major < other.major
+	}
+	if v.minor != other.minor {
+		return v.minor < other.minor
+	}
+	return v.patch < other.patch
+}
+
+// releaseTag matches the tags of descheduler release images, such as v0.29.0, 0.29 or v0.29.0-amd64
+var releaseTag = regexp.MustCompile(`^v?(\d+)\.(\d+)(?:\.(\d+))?(?:[-+].*)?$`)
+
+// parseImageVersion returns the descheduler release of the image tag, false when the image has no tag or its tag
+// isn't a release version, e.g. latest or a digest. The tag is read as an upstream release whatever the registry,
+// so builds of a fork, such as DefaultImage, must be tagged with the upstream release they are built from.
+func parseImageVersion(image string) (releaseVersion, bool) {
+	if i := strings.Index(image, "@"); i >= 0 {
+		image = image[:i]
+	}
+	i := strings.LastIndex(image, ":")
+	if i < 0 || strings.Contains(image[i:], "/") {
+		// The colon is the port of the registry
+		return releaseVersion{}, false
+	}
+	match := releaseTag.FindStringSubmatch(image[i+1:])
+	if match == nil {
+		return releaseVersion{}, false
+	}
+	version := releaseVersion{}
+	version.major, _ = strconv.Atoi(match[1])
+	version.minor, _ = strconv.Atoi(match[2])
+	if len(match[3]) != 0 {
+		version.patch, _ = strconv.Atoi(match[3])
+	}
+	return version, true
+}
+
+// supportRange is the releases supporting a setting, from since to the release removing it, until is zero while
+// the setting is supported
+type supportRange struct {
+	since releaseVersion
+	until releaseVersion
+}
+
+func (s supportRange) supports(version releaseVersion) bool {
+	return !version.less(s.since) && (s.until == releaseVersion{} || version.less(s.until))
+}
+
+// unsupported describes why the release doesn't support the setting
+func (s supportRange) unsupported(setting string, version releaseVersion) string {
+	if version.less(s.since) {
+		return fmt.Sprintf("%s needs descheduler %s or later", setting, s.since)
+	}
+	return fmt.Sprintf("%s was removed in descheduler %s", setting, s.until)
+}
+
+// policyVersionSupport are the releases reading each policy version
+var policyVersionSupport = map[deschedulerv1beta1.PolicyVersion]supportRange{
+	deschedulerv1beta1.PolicyVersionV1alpha1: {until: releaseVersion{0, 30, 0}},
+	deschedulerv1beta1.PolicyVersionV1alpha2: {since: releaseVersion{0, 29, 0}},
+}
+
+// strategySupport are the releases running each strategy, by its field in the spec
+var strategySupport = map[string]supportRange{
+	"removeDuplicates":                            {},
+	"lowNodeUtilization":                          {},
+	"removePodsViolatingInterPodAntiAffinity":     {},
+	"removePodsViolatingNodeAffinity":             {},
+	"removePodsViolatingNodeTaints":               {since: releaseVersion{0, 10, 0}},
+	"removePodsHavingTooManyRestarts":             {since: releaseVersion{0, 10, 0}},
+	"podLifeTime":                                 {since: releaseVersion{0, 10, 0}},
+	"removePodsViolatingTopologySpreadConstraint": {since: releaseVersion{0, 20, 0}},
+	"highNodeUtilization":                         {since: releaseVersion{0, 21, 0}},
+	"removeFailedPods":                            {since: releaseVersion{0, 22, 0}},
+}
+
+// flagSupport are the releases accepting each flag of spec.flags
+var flagSupport = map[string]supportRange{
+	deschedulerv1beta1.FlagDeschedulingInterval: {},
+	deschedulerv1beta1.FlagDryRun:               {},
+	deschedulerv1beta1.FlagNodeSelector:         {until: releaseVersion{0, 27, 0}},
+}
+
+// settingSupport are the releases reading the other settings of the policy, by their field in the spec
+var settingSupport = map[string]supportRange{
+	"namespaces":                     {since: releaseVersion{0, 18, 0}},
+	"thresholdPriority":              {since: releaseVersion{0, 19, 0}},
+	"thresholdPriorityClassName":     {since: releaseVersion{0, 19, 0}},
+	"evictLocalStoragePods":          {since: releaseVersion{0, 18, 0}},
+	"evictSystemCriticalPods":        {since: releaseVersion{0, 20, 0}},
+	"ignorePvcPods":                  {since: releaseVersion{0, 21, 0}},
+	"maxNoOfPodsToEvictPerNode":      {since: releaseVersion{0, 18, 0}},
+	"maxNoOfPodsToEvictPerNamespace": {since: releaseVersion{0, 23, 0}},
+}
+
+// policyVersion returns the policy version rendered for the spec: spec.policyVersion when set, otherwise v1alpha1
+// as long as the release of the image reads it, so the policy of existing Deschedulers doesn't change
+func policyVersion(spec deschedulerv1beta1.DeschedulerSpec) deschedulerv1beta1.PolicyVersion {
+	if len(spec.PolicyVersion) != 0 {
+		return spec.PolicyVersion
+	}
+	version, ok := parseImageVersion(spec.Image)
+	if ok && !policyVersionSupport[deschedulerv1beta1.PolicyVersionV1alpha1].supports(version) {
+		return deschedulerv1beta1.PolicyVersionV1alpha2
+	}
+	return deschedulerv1beta1.PolicyVersionV1alpha1
+}
+
+// nodeSelector returns the node-selector flag of the spec, it is rendered in the policy too
+func nodeSelector(spec deschedulerv1beta1.DeschedulerSpec) string {
+	for _, flag := range spec.Flags {
+		if flag.Name == deschedulerv1beta1.FlagNodeSelector {
+			return flag.Value
+		}
+	}
+	return ""
+}
+
+// commandFlags returns the flags of the spec passed to descheduler. The node-selector flag is left out for the
+// releases removing it when the operator renders the policy, the policy carries the node selector.
+func commandFlags(spec deschedulerv1beta1.DeschedulerSpec) []deschedulerv1beta1.Param {
+	version, ok := parseImageVersion(spec.Image)
+	if spec.Policy != nil || !ok || flagSupport[deschedulerv1beta1.FlagNodeSelector].supports(version) {
+		return spec.Flags
+	}
+	var flags []deschedulerv1beta1.Param
+	for _, flag := range spec.Flags {
+		if flag.Name != deschedulerv1beta1.FlagNodeSelector {
+			flags = append(flags, flag)
+		}
+	}
+	return flags
+}
+
+// UnsupportedSettings returns the settings of the spec the descheduler release of its image doesn't support, and
+// false when the image tag isn't a release version and can't be checked
+func UnsupportedSettings(spec deschedulerv1beta1.DeschedulerSpec) ([]string, bool) {
+	version, ok := parseImageVersion(spec.Image)
+	if !ok {
+		return nil, false
+	}
+	var unsupported []string
+	check := func(support supportRange, setting string) {
+		if !support.supports(version) {
+			unsupported = append(unsupported, support.unsupported(setting, version))
+		}
+	}
+
+	if policySource := spec.Policy; policySource != nil {
+		// Only the version of a policy maintained outside of the spec is checked
+		if content, err := renderPolicySource(policySource); err == nil {
+			if policy, err := ValidatePolicy([]byte(content)); err == nil {
+				check(policyVersionSupport[policy], fmt.Sprintf("policy apiVersion descheduler/%s", policy))
+			}
+		}
+	} else {
+		policy := policyVersion(spec)
+		check(policyVersionSupport[policy], fmt.Sprintf("policyVersion %s", policy))
+		if policy == deschedulerv1beta1.PolicyVersionV1alpha2 {
+			// The v1alpha2 policy only has the priority threshold of the profile, the ones of strategies are lost
+			for _, strategy := range strategyPriorityThresholds(spec.Strategies) {
+				unsupported = append(unsupported, fmt.Sprintf(
+					"the priority threshold of strategy %s isn't read with policyVersion %s, set the one of the spec", strategy, policy))
+			}
+		}
+	}
+	settings := map[string]bool{
+		"thresholdPriority":              spec.ThresholdPriority != nil,
+		"thresholdPriorityClassName":     len(spec.ThresholdPriorityClassName) != 0,
+		"evictLocalStoragePods":          spec.EvictLocalStoragePods,
+		"evictSystemCriticalPods":        spec.EvictSystemCriticalPods,
+		"ignorePvcPods":                  spec.IgnorePvcPods,
+		"maxNoOfPodsToEvictPerNode":      spec.MaxNoOfPodsToEvictPerNode != nil,
+		"maxNoOfPodsToEvictPerNamespace": spec.MaxNoOfPodsToEvictPerNamespace != nil,
+	}
+	for _, setting := range strategySettings(spec.Strategies) {
+		settings[setting] = true
+	}
+	for _, profile := range spec.Profiles {
+		for _, setting := range strategySettings(profile.Strategies) {
+			settings[setting] = true
+		}
+		settings["thresholdPriority"] = settings["thresholdPriority"] || profile.ThresholdPriority != nil
+		settings["thresholdPriorityClassName"] = settings["thresholdPriorityClassName"] || len(profile.ThresholdPriorityClassName) != 0
+	}
+	for setting, set := range settings {
+		if !set {
+			continue
+		}
+		if support, ok := strategySupport[setting]; ok {
+			check(support, fmt.Sprintf("strategy %s", setting))
+		} else if support, ok := settingSupport[setting]; ok {
+			check(support, setting)
+		}
+	}
+	for _, flag := range commandFlags(spec) {
+		if support, ok := flagSupport[flag.Name]; ok {
+			check(support, fmt.Sprintf("flag %s", flag.Name))
+		}
+	}
+	sort.Strings(unsupported)
+	return unsupported, true
+}
+
+// strategySettings returns the enabled strategies and the params they set, by their field in the spec
+func strategySettings(strategies deschedulerv1beta1.DeschedulerStrategies) []string {
+	var settings []string
+	enabled := map[string]map[string]interface{}{}
+	if content, err := json.Marshal(strategies); err == nil && json.Unmarshal(content, &enabled) == nil {
+		for strategy, params := range enabled {
+			settings = append(settings, strategy)
+			for param := range params {
+				settings = append(settings, param)
+			}
+		}
+	}
+	return settings
+}
+
+// strategyPriorityThresholds returns the enabled strategies setting a priority threshold, by their field in the spec
+func strategyPriorityThresholds(strategies deschedulerv1beta1.DeschedulerStrategies) []string {
+	var thresholds []string
+	enabled := map[string]map[string]interface{}{}
+	if content, err := json.Marshal(strategies); err == nil && json.Unmarshal(content, &enabled) == nil {
+		for strategy, params := range enabled {
+			_, priority := params["thresholdPriority"]
+			_, priorityClassName := params["thresholdPriorityClassName"]
+			if priority || priorityClassName {
+				thresholds = append(thresholds, strategy)
+			}
+		}
+	}
+	return thresholds
+}
@@ -0,0 +1,107 @@
+package descheduler
+
+import (
+	"reflect"
+	"testing"
+
+	deschedulerv1beta1 "github.com/skckadiyala/descheduler-operator/pkg/apis/descheduler/v1beta1"
+)
+
+func TestParseImageVersion(t *testing.T) {
+	tests := []struct {
+		image   string
+		version releaseVersion
+		ok      bool
+	}{
+		{"registry.k8s.io/descheduler/descheduler:v0.29.0", releaseVersion{0, 29, 0}, true},
+		{"descheduler:0.29", releaseVersion{0, 29, 0}, true},
+		{"descheduler:v0.29.1-amd64", releaseVersion{0, 29, 1}, true},
+		{"registry.local:5000/descheduler/descheduler:v0.22.0", releaseVersion{0, 22, 0}, true},
+		{"registry.local:5000/descheduler/descheduler", releaseVersion{}, false},
+		{"descheduler:v0.22.0@sha256:0a1b2c3d4e5f", releaseVersion{0, 22, 0}, true},
+		{"descheduler@sha256:0a1b2c3d4e5f", releaseVersion{}, false},
+		{"descheduler:latest", releaseVersion{}, false},
+		{"descheduler:main-20240101", releaseVersion{}, false},
+		{"descheduler", releaseVersion{}, false},
+		// The fork tags its images with the upstream release they are built from
+		{deschedulerv1beta1.DefaultImage, releaseVersion{0, 9, 0}, true},
+	}
+	for _, test := range tests {
+		version, ok := parseImageVersion(test.image)
+		if version != test.version || ok != test.ok {
+			t.Errorf("%s: expected %v %v, got %v %v", test.image, test.version, test.ok, version, ok)
+		}
+	}
+}
+
+func TestUnsupportedSettings(t *testing.T) {
+	priority := int32(1000)
+	tests := []struct {
+		name        string
+		edit        func(spec *deschedulerv1beta1.DeschedulerSpec)
+		unsupported []string
+		ok          bool
+	}{
+		{"default spec", func(spec *deschedulerv1beta1.DeschedulerSpec) {}, nil, true},
+		{"unknown version", func(spec *deschedulerv1beta1.DeschedulerSpec) {
+			spec.Image = "descheduler:latest"
+			spec.IgnorePvcPods = true
+		}, nil, false},
+		{"settings of later releases", func(spec *deschedulerv1beta1.DeschedulerSpec) {
+			spec.Image = "descheduler:v0.20.1"
+			spec.IgnorePvcPods = true
+			spec.Strategies.RemoveFailedPods = &deschedulerv1beta1.RemoveFailedPodsStrategy{}
+		}, []string{
+			"ignorePvcPods needs descheduler v0.21.0 or later",
+			"strategy removeFailedPods needs descheduler v0.22.0 or later",
+		}, true},
+		{"params and profiles", func(spec *deschedulerv1beta1.DeschedulerSpec) {
+			spec.PolicyVersion = deschedulerv1beta1.PolicyVersionV1alpha2
+			spec.Profiles = []deschedulerv1beta1.DeschedulerProfile{{
+				Name: "default",
+				Strategies: deschedulerv1beta1.DeschedulerStrategies{
+					RemoveDuplicates: &deschedulerv1beta1.RemoveDuplicatesStrategy{
+						Namespaces: &deschedulerv1beta1.Namespaces{Exclude: []string{"kube-system"}},
+					},
+				},
+				PriorityThreshold: deschedulerv1beta1.PriorityThreshold{ThresholdPriority: &priority},
+			}}
+		}, []string{
+			"namespaces needs descheduler v0.18.0 or later",
+			"policyVersion v1alpha2 needs descheduler v0.29.0 or later",
+			"thresholdPriority needs descheduler v0.19.0 or later",
+		}, true},
+		{"removed policy version", func(spec *deschedulerv1beta1.DeschedulerSpec) {
+			spec.Image = "descheduler:v0.30.0"
+			spec.PolicyVersion = deschedulerv1beta1.PolicyVersionV1alpha1
+		}, []string{"policyVersion v1alpha1 was removed in descheduler v0.30.0"}, true},
+		{"policy version of the release", func(spec *deschedulerv1beta1.DeschedulerSpec) {
+			spec.Image = "descheduler:v0.30.0"
+		}, nil, true},
+		{"strategy priority thresholds with the policy version of the release", func(spec *deschedulerv1beta1.DeschedulerSpec) {
+			spec.Image = "descheduler:v0.30.0"
+			spec.Strategies.RemoveDuplicates.ThresholdPriority = &priority
+			spec.Strategies.PodLifeTime = &deschedulerv1beta1.PodLifeTimeStrategy{
+				MaxPodLifeTimeSeconds: 3600,
+				PriorityThreshold:     deschedulerv1beta1.PriorityThreshold{ThresholdPriorityClassName: "critical"},
+			}
+		}, []string{
+			"the priority threshold of strategy podLifeTime isn't read with policyVersion v1alpha2, set the one of the spec",
+			"the priority threshold of strategy removeDuplicates isn't read with policyVersion v1alpha2, set the one of the spec",
+		}, true},
+		{"strategy priority thresholds with v1alpha1", func(spec *deschedulerv1beta1.DeschedulerSpec) {
+			spec.Image = "descheduler:v0.29.0"
+			spec.Strategies.RemoveDuplicates.ThresholdPriority = &priority
+		}, nil, true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			descheduler := testDescheduler()
+			test.edit(&descheduler.Spec)
+			unsupported, ok := UnsupportedSettings(descheduler.Spec)
+			if !reflect.DeepEqual(unsupported, test.unsupported) || ok != test.ok {
+				t.Errorf("expected %q %v, got %q %v", test.unsupported, test.ok, unsupported, ok)
+			}
+		})
+	}
+}
@@ -2,12 +2,12 @@ package descheduler
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"log"
-	"strconv"
-	"strings"
 
-	deschedulerv1alpha1 "github.com/skckadiyala/descheduler-operator/pkg/apis/descheduler/v1alpha1"
+	deschedulerv1beta1 "github.com/skckadiyala/descheduler-operator/pkg/apis/descheduler/v1beta1"
 	"gopkg.in/yaml.v2"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
@@ -37,73 +37,195 @@ type Policy struct {
 						Pods   int `yaml:",omitempty"`
 					} `yaml:"thresholds"`
 				} `yaml:"nodeResourceUtilizationThresholds"`
+				PolicyPriorityThreshold `yaml:",inline"`
 			} `yaml:"params"`
 		} `yaml:"LowNodeUtilization"`
 		RemoveDuplicates struct {
 			Enabled bool `yaml:"enabled"`
+			Params  struct {
+				Namespaces              *PolicyNamespaces `yaml:"namespaces,omitempty"`
+				PolicyPriorityThreshold `yaml:",inline"`
+			} `yaml:"params,omitempty"`
 		} `yaml:"RemoveDuplicates"`
 		RemovePodsViolatingInterPodAntiAffinity struct {
 			Enabled bool `yaml:"enabled"`
+			Params  struct {
+				Namespaces              *PolicyNamespaces `yaml:"namespaces,omitempty"`
+				PolicyPriorityThreshold `yaml:",inline"`
+			} `yaml:"params,omitempty"`
 		} `yaml:"RemovePodsViolatingInterPodAntiAffinity"`
 		RemovePodsViolatingNodeAffinity struct {
 			Enabled bool `yaml:"enabled"`
 			Params  struct {
-				NodeAffinityType []string `yaml:"nodeAffinityType"`
+				NodeAffinityType        []string          `yaml:"nodeAffinityType"`
+				Namespaces              *PolicyNamespaces `yaml:"namespaces,omitempty"`
+				PolicyPriorityThreshold `yaml:",inline"`
 			} `yaml:"params"`
 		} `yaml:"RemovePodsViolatingNodeAffinity"`
+		// The strategies added after descheduler v0.9 are only rendered when enabled, so the policy of
+		// Deschedulers not using them stays the same
+		RemovePodsViolatingNodeTaints               *NodeTaintsPolicy          `yaml:"RemovePodsViolatingNodeTaints,omitempty"`
+		RemovePodsHavingTooManyRestarts             *TooManyRestartsPolicy     `yaml:"RemovePodsHavingTooManyRestarts,omitempty"`
+		PodLifeTime                                 *PodLifeTimePolicy         `yaml:"PodLifeTime,omitempty"`
+		RemovePodsViolatingTopologySpreadConstraint *TopologySpreadPolicy      `yaml:"RemovePodsViolatingTopologySpreadConstraint,omitempty"`
+		HighNodeUtilization                         *HighNodeUtilizationPolicy `yaml:"HighNodeUtilization,omitempty"`
+		RemoveFailedPods                            *FailedPodsPolicy          `yaml:"RemoveFailedPods,omitempty"`
 	} `yaml:"strategies"`
+	NodeSelector                   string `yaml:"nodeSelector,omitempty"`
+	EvictLocalStoragePods          bool   `yaml:"evictLocalStoragePods,omitempty"`
+	EvictSystemCriticalPods        bool   `yaml:"evictSystemCriticalPods,omitempty"`
+	IgnorePvcPods                  bool   `yaml:"ignorePvcPods,omitempty"`
+	MaxNoOfPodsToEvictPerNode      *int32 `yaml:"maxNoOfPodsToEvictPerNode,omitempty"`
+	MaxNoOfPodsToEvictPerNamespace *int32 `yaml:"maxNoOfPodsToEvictPerNamespace,omitempty"`
+}
+
+// PolicyNamespaces is the namespaces filter of a strategy in the policy.yaml file
+type PolicyNamespaces struct {
+	Include []string `yaml:"include,omitempty"`
+	Exclude []string `yaml:"exclude,omitempty"`
+}
+
+// PolicyPriorityThreshold is the priority from which a strategy doesn't evict pods in the policy.yaml file
+type PolicyPriorityThreshold struct {
+	ThresholdPriority          *int32 `yaml:"thresholdPriority,omitempty"`
+	ThresholdPriorityClassName string `yaml:"thresholdPriorityClassName,omitempty"`
+}
+
+// NodeTaintsPolicy is the RemovePodsViolatingNodeTaints strategy of the policy.yaml file
+type NodeTaintsPolicy struct {
+	Enabled bool `yaml:"enabled"`
+	Params  struct {
+		ExcludedTaints          []string          `yaml:"excludedTaints,omitempty"`
+		IncludePreferNoSchedule bool              `yaml:"includePreferNoSchedule,omitempty"`
+		Namespaces              *PolicyNamespaces `yaml:"namespaces,omitempty"`
+		PolicyPriorityThreshold `yaml:",inline"`
+	} `yaml:"params"`
+}
+
+// TooManyRestartsPolicy is the RemovePodsHavingTooManyRestarts strategy of the policy.yaml file
+type TooManyRestartsPolicy struct {
+	Enabled bool `yaml:"enabled"`
+	Params  struct {
+		PodsHavingTooManyRestarts struct {
+			PodRestartThreshold     int  `yaml:"podRestartThreshold"`
+			IncludingInitContainers bool `yaml:"includingInitContainers,omitempty"`
+		} `yaml:"podsHavingTooManyRestarts"`
+		Namespaces              *PolicyNamespaces `yaml:"namespaces,omitempty"`
+		PolicyPriorityThreshold `yaml:",inline"`
+	} `yaml:"params"`
+}
+
+// PodLifeTimePolicy is the PodLifeTime strategy of the policy.yaml file
+type PodLifeTimePolicy struct {
+	Enabled bool `yaml:"enabled"`
+	Params  struct {
+		PodLifeTime struct {
+			MaxPodLifeTimeSeconds int64    `yaml:"maxPodLifeTimeSeconds"`
+			PodStatusPhases       []string `yaml:"podStatusPhases,omitempty"`
+		} `yaml:"podLifeTime"`
+		Namespaces              *PolicyNamespaces `yaml:"namespaces,omitempty"`
+		PolicyPriorityThreshold `yaml:",inline"`
+	} `yaml:"params"`
+}
+
+// TopologySpreadPolicy is the RemovePodsViolatingTopologySpreadConstraint strategy of the policy.yaml file
+type TopologySpreadPolicy struct {
+	Enabled bool `yaml:"enabled"`
+	Params  struct {
+		IncludeSoftConstraints  bool              `yaml:"includeSoftConstraints,omitempty"`
+		Namespaces              *PolicyNamespaces `yaml:"namespaces,omitempty"`
+		PolicyPriorityThreshold `yaml:",inline"`
+	} `yaml:"params"`
+}
+
+// HighNodeUtilizationPolicy is the HighNodeUtilization strategy of the policy.yaml file
+type HighNodeUtilizationPolicy struct {
+	Enabled bool `yaml:"enabled"`
+	Params  struct {
+		NodeResourceUtilizationThresholds struct {
+			NumberOfNodes int `yaml:"numberOfNodes,omitempty"`
+			Thresholds    struct {
+				CPU    int `yaml:",omitempty"`
+				Memory int `yaml:",omitempty"`
+				Pods   int `yaml:",omitempty"`
+			} `yaml:"thresholds"`
+		} `yaml:"nodeResourceUtilizationThresholds"`
+		PolicyPriorityThreshold `yaml:",inline"`
+	} `yaml:"params"`
+}
+
+// FailedPodsPolicy is the RemoveFailedPods strategy of the policy.yaml file
+type FailedPodsPolicy struct {
+	Enabled bool `yaml:"enabled"`
+	Params  struct {
+		FailedPods struct {
+			Reasons                 []string `yaml:"reasons,omitempty"`
+			IncludingInitContainers bool     `yaml:"includingInitContainers,omitempty"`
+			ExcludeOwnerKinds       []string `yaml:"excludeOwnerKinds,omitempty"`
+			MinPodLifetimeSeconds   int64    `yaml:"minPodLifetimeSeconds,omitempty"`
+		} `yaml:"failedPods"`
+		Namespaces              *PolicyNamespaces `yaml:"namespaces,omitempty"`
+		PolicyPriorityThreshold `yaml:",inline"`
+	} `yaml:"params"`
 }
 
-// generateConfigMap generates configmap needed for the descheduler from CR
-func (r *ReconcileDescheduler) generateConfigMap(descheduler *deschedulerv1alpha1.Descheduler) error {
+// generateConfigMap generates configmap needed for the descheduler from CR. The policy of an existing
+// configmap is updated in place, it is recreated only when the update is rejected.
+func (r *ReconcileDescheduler) generateConfigMap(descheduler *deschedulerv1beta1.Descheduler) error {
 	deschedulerConfigMap := &v1.ConfigMap{}
 	err := r.client.Get(context.TODO(), types.NamespacedName{Name: descheduler.Name, Namespace: descheduler.Namespace}, deschedulerConfigMap)
 	if err != nil && errors.IsNotFound(err) {
 		//Create a new ConfigMap
 		cm, err := r.createConfigMap(descheduler)
 		if err != nil {
-			log.Fatalf("%v", err)
+			log.Printf("%v", err)
 			return err
 		}
 		err = r.client.Create(context.TODO(), cm)
 		if err != nil {
-			log.Fatalf("%v", err)
+			log.Printf("%v", err)
 			return err
 		}
-	} else if !CheckIfPropertyChanges(descheduler.Spec.Strategies, deschedulerConfigMap.Data) {
-		fmt.Println("Strategy mismatch in configmap, Delete it")
-		err = r.client.Delete(context.TODO(), deschedulerConfigMap)
-		if err != nil {
-			log.Printf("Error while deleteing configmap")
-			return err
-		}
-		return r.updateDeschedulerStatus(descheduler, Updating)
+		r.recordCreated(descheduler, "ConfigMap", cm.Name)
+		return nil
 	} else if err != nil {
 		return err
+	} else if err := r.claim(descheduler, deschedulerConfigMap, "ConfigMap"); err != nil {
+		return err
+	} else if unchanged, err := CheckIfPropertyChanges(effectiveSpec(descheduler), deschedulerConfigMap.Data); err != nil || unchanged {
+		return err
 	}
-	return nil
-}
 
-func (r *ReconcileDescheduler) updateDeschedulerStatus(descheduler *deschedulerv1alpha1.Descheduler, desiredPhase string) error {
-	currentPhase := descheduler.Status.Phase
-	descheduler.Status.Phase = desiredPhase
-	if currentPhase == desiredPhase {
-		return nil
-	}
-	log.Printf("Updating descheduler status ")
-	err := r.client.Update(context.TODO(), descheduler)
+	log.Printf("Policy of configmap %s is outdated, updating it", deschedulerConfigMap.Name)
+	cm, err := r.createConfigMap(descheduler)
 	if err != nil {
-		log.Printf("Failed to update descheduler status from %v to %v", currentPhase, desiredPhase)
 		return err
 	}
+	if deschedulerConfigMap.Data == nil {
+		deschedulerConfigMap.Data = map[string]string{}
+	}
+	for key, value := range cm.Data {
+		deschedulerConfigMap.Data[key] = value
+	}
+	err = r.client.Update(context.TODO(), deschedulerConfigMap)
+	if err != nil && errors.IsInvalid(err) {
+		// The running descheduler mounts the config map, it is kept until the policy can be stored
+		log.Printf("Configmap can't be updated in place %v", err)
+		return fmt.Errorf("config map %s can't be updated in place: %v", deschedulerConfigMap.Name, err)
+	} else if err != nil {
+		log.Printf("Error while updating configmap %v", err)
+		return err
+	}
+	r.recordUpdated(descheduler, "ConfigMap", deschedulerConfigMap.Name)
 	return nil
 }
 
-func (r *ReconcileDescheduler) createConfigMap(descheduler *deschedulerv1alpha1.Descheduler) (*v1.ConfigMap, error) {
+func (r *ReconcileDescheduler) createConfigMap(descheduler *deschedulerv1beta1.Descheduler) (*v1.ConfigMap, error) {
 	log.Printf("Creating config map")
-	deschedulerPolicy := &Policy{}
-
-	strategiesPolicyString := generateConfigMapString(descheduler.Spec.Strategies, *deschedulerPolicy)
+	strategiesPolicyString, err := generateConfigMapString(effectiveSpec(descheduler))
+	if err != nil {
+		return nil, err
+	}
 	log.Printf("strategiesPolicy: %v", strategiesPolicyString)
 
 	cm := &v1.ConfigMap{
@@ -119,72 +241,175 @@ func (r *ReconcileDescheduler) createConfigMap(descheduler *deschedulerv1alpha1.
 			"policy.yaml": strategiesPolicyString,
 		},
 	}
-	err := controllerutil.SetControllerReference(descheduler, cm, r.scheme)
+	err = controllerutil.SetControllerReference(descheduler, cm, r.scheme)
 	if err != nil {
 		return nil, fmt.Errorf("error setting owner references %v", err)
 	}
 	return cm, nil
 }
 
-func generateConfigMapString(requestedStrategies []deschedulerv1alpha1.Strategy, policy Policy) string {
+// generateConfigMapString renders the policy.yaml of the spec with the renderer of its policy version, spec.policy
+// is used as is
+func generateConfigMapString(spec deschedulerv1beta1.DeschedulerSpec) (string, error) {
+	if spec.Policy != nil {
+		return renderPolicySource(spec.Policy)
+	}
+	renderer, err := RendererFor(spec)
+	if err != nil {
+		return "", err
+	}
+	return renderer.Render(spec)
+}
+
+// GeneratePolicy returns the descheduler policy of the spec, generateConfigMapString renders it in the config map
+func GeneratePolicy(spec deschedulerv1beta1.DeschedulerSpec) Policy {
 	// There is no need to do validation here. By the time, we reach here, validation would have already happened.
+	requestedStrategies := spec.Strategies
+	policy := Policy{}
 	policy.APIVersion = "descheduler/v1alpha1"
 	policy.Kind = "DeschedulerPolicy"
-	for _, strategy := range requestedStrategies {
-		switch strings.ToLower(strategy.Name) {
-		case "duplicates":
-			policy.Strategies.RemoveDuplicates.Enabled = true
-		case "interpodantiaffinity":
-			policy.Strategies.RemovePodsViolatingInterPodAntiAffinity.Enabled = true
-		case "lownodeutilization":
-			policy.Strategies.LowNodeUtilization.Enabled = true
-			if len(strategy.Params) > 0 {
-				for _, param := range strategy.Params {
-					if !strings.Contains(strings.ToUpper(param.Name), strings.ToUpper("target")) {
-						switch param.Name {
-						case "cputhreshold":
-							policy.Strategies.LowNodeUtilization.Params.NodeResourceUtilizationThresholds.Thresholds.CPU, _ = strconv.Atoi(param.Value)
-						case "memorythreshold":
-							policy.Strategies.LowNodeUtilization.Params.NodeResourceUtilizationThresholds.Thresholds.Memory, _ = strconv.Atoi(param.Value)
-						case "podsthreshold":
-							policy.Strategies.LowNodeUtilization.Params.NodeResourceUtilizationThresholds.Thresholds.Pods, _ = strconv.Atoi(param.Value)
-						}
-					} else {
-						switch param.Name {
-						case "cputargetthreshold":
-							policy.Strategies.LowNodeUtilization.Params.NodeResourceUtilizationThresholds.TargetThresholds.CPU, _ = strconv.Atoi(param.Value)
-						case "memorytargetthreshold":
-							policy.Strategies.LowNodeUtilization.Params.NodeResourceUtilizationThresholds.TargetThresholds.Memory, _ = strconv.Atoi(param.Value)
-						case "podstargetthreshold":
-							policy.Strategies.LowNodeUtilization.Params.NodeResourceUtilizationThresholds.TargetThresholds.Pods, _ = strconv.Atoi(param.Value)
-						}
-					}
-					if param.Name == "nodes" {
-						policy.Strategies.LowNodeUtilization.Params.NodeResourceUtilizationThresholds.NumberOfNodes, _ = strconv.Atoi(param.Value)
-					}
-				}
-			}
-		case "nodeaffinity":
-			policy.Strategies.RemovePodsViolatingNodeAffinity.Enabled = true
-			nodeAffinity := []string{"requiredDuringSchedulingIgnoredDuringExecution"}
-			policy.Strategies.RemovePodsViolatingNodeAffinity.Params.NodeAffinityType = append(nodeAffinity, policy.Strategies.RemovePodsViolatingNodeAffinity.Params.NodeAffinityType...)
-		default:
-			// Accept no other strategy except for the valid ones.
+	policy.NodeSelector = nodeSelector(spec)
+	policy.EvictLocalStoragePods = spec.EvictLocalStoragePods
+	policy.EvictSystemCriticalPods = spec.EvictSystemCriticalPods
+	policy.IgnorePvcPods = spec.IgnorePvcPods
+	policy.MaxNoOfPodsToEvictPerNode = spec.MaxNoOfPodsToEvictPerNode
+	policy.MaxNoOfPodsToEvictPerNamespace = spec.MaxNoOfPodsToEvictPerNamespace
+	if removeDuplicates := requestedStrategies.RemoveDuplicates; removeDuplicates != nil {
+		policy.Strategies.RemoveDuplicates.Enabled = true
+		policy.Strategies.RemoveDuplicates.Params.Namespaces = policyNamespaces(removeDuplicates.Namespaces)
+		policy.Strategies.RemoveDuplicates.Params.PolicyPriorityThreshold = policyPriorityThreshold(removeDuplicates.PriorityThreshold, spec.PriorityThreshold)
+	}
+	if interPodAntiAffinity := requestedStrategies.RemovePodsViolatingInterPodAntiAffinity; interPodAntiAffinity != nil {
+		policy.Strategies.RemovePodsViolatingInterPodAntiAffinity.Enabled = true
+		policy.Strategies.RemovePodsViolatingInterPodAntiAffinity.Params.Namespaces = policyNamespaces(interPodAntiAffinity.Namespaces)
+		policy.Strategies.RemovePodsViolatingInterPodAntiAffinity.Params.PolicyPriorityThreshold = policyPriorityThreshold(interPodAntiAffinity.PriorityThreshold, spec.PriorityThreshold)
+	}
+	if lowNodeUtilization := requestedStrategies.LowNodeUtilization; lowNodeUtilization != nil {
+		policy.Strategies.LowNodeUtilization.Enabled = true
+		nodeThresholds := &policy.Strategies.LowNodeUtilization.Params.NodeResourceUtilizationThresholds
+		nodeThresholds.NumberOfNodes = int(lowNodeUtilization.NumberOfNodes)
+		nodeThresholds.Thresholds.CPU = int(lowNodeUtilization.Thresholds.CPU)
+		nodeThresholds.Thresholds.Memory = int(lowNodeUtilization.Thresholds.Memory)
+		nodeThresholds.Thresholds.Pods = int(lowNodeUtilization.Thresholds.Pods)
+		nodeThresholds.TargetThresholds.CPU = int(lowNodeUtilization.TargetThresholds.CPU)
+		nodeThresholds.TargetThresholds.Memory = int(lowNodeUtilization.TargetThresholds.Memory)
+		nodeThresholds.TargetThresholds.Pods = int(lowNodeUtilization.TargetThresholds.Pods)
+		policy.Strategies.LowNodeUtilization.Params.PolicyPriorityThreshold = policyPriorityThreshold(lowNodeUtilization.PriorityThreshold, spec.PriorityThreshold)
+	}
+	if nodeAffinity := requestedStrategies.RemovePodsViolatingNodeAffinity; nodeAffinity != nil {
+		policy.Strategies.RemovePodsViolatingNodeAffinity.Enabled = true
+		policy.Strategies.RemovePodsViolatingNodeAffinity.Params.NodeAffinityType = nodeAffinity.NodeAffinityType
+		if len(nodeAffinity.NodeAffinityType) == 0 {
+			policy.Strategies.RemovePodsViolatingNodeAffinity.Params.NodeAffinityType = []string{"requiredDuringSchedulingIgnoredDuringExecution"}
+		}
+		policy.Strategies.RemovePodsViolatingNodeAffinity.Params.Namespaces = policyNamespaces(nodeAffinity.Namespaces)
+		policy.Strategies.RemovePodsViolatingNodeAffinity.Params.PolicyPriorityThreshold = policyPriorityThreshold(nodeAffinity.PriorityThreshold, spec.PriorityThreshold)
+	}
+	if nodeTaints := requestedStrategies.RemovePodsViolatingNodeTaints; nodeTaints != nil {
+		strategy := &NodeTaintsPolicy{Enabled: true}
+		strategy.Params.ExcludedTaints = nodeTaints.ExcludedTaints
+		strategy.Params.IncludePreferNoSchedule = nodeTaints.IncludePreferNoSchedule
+		strategy.Params.Namespaces = policyNamespaces(nodeTaints.Namespaces)
+		strategy.Params.PolicyPriorityThreshold = policyPriorityThreshold(nodeTaints.PriorityThreshold, spec.PriorityThreshold)
+		policy.Strategies.RemovePodsViolatingNodeTaints = strategy
+	}
+	if tooManyRestarts := requestedStrategies.RemovePodsHavingTooManyRestarts; tooManyRestarts != nil {
+		strategy := &TooManyRestartsPolicy{Enabled: true}
+		strategy.Params.PodsHavingTooManyRestarts.PodRestartThreshold = int(tooManyRestarts.PodRestartThreshold)
+		strategy.Params.PodsHavingTooManyRestarts.IncludingInitContainers = tooManyRestarts.IncludingInitContainers
+		strategy.Params.Namespaces = policyNamespaces(tooManyRestarts.Namespaces)
+		strategy.Params.PolicyPriorityThreshold = policyPriorityThreshold(tooManyRestarts.PriorityThreshold, spec.PriorityThreshold)
+		policy.Strategies.RemovePodsHavingTooManyRestarts = strategy
+	}
+	if podLifeTime := requestedStrategies.PodLifeTime; podLifeTime != nil {
+		strategy := &PodLifeTimePolicy{Enabled: true}
+		strategy.Params.PodLifeTime.MaxPodLifeTimeSeconds = podLifeTime.MaxPodLifeTimeSeconds
+		for _, phase := range podLifeTime.PodStatusPhases {
+			strategy.Params.PodLifeTime.PodStatusPhases = append(strategy.Params.PodLifeTime.PodStatusPhases, string(phase))
 		}
+		strategy.Params.Namespaces = policyNamespaces(podLifeTime.Namespaces)
+		strategy.Params.PolicyPriorityThreshold = policyPriorityThreshold(podLifeTime.PriorityThreshold, spec.PriorityThreshold)
+		policy.Strategies.PodLifeTime = strategy
+	}
+	if topologySpread := requestedStrategies.RemovePodsViolatingTopologySpreadConstraint; topologySpread != nil {
+		strategy := &TopologySpreadPolicy{Enabled: true}
+		strategy.Params.IncludeSoftConstraints = topologySpread.IncludeSoftConstraints
+		strategy.Params.Namespaces = policyNamespaces(topologySpread.Namespaces)
+		strategy.Params.PolicyPriorityThreshold = policyPriorityThreshold(topologySpread.PriorityThreshold, spec.PriorityThreshold)
+		policy.Strategies.RemovePodsViolatingTopologySpreadConstraint = strategy
+	}
+	if highNodeUtilization := requestedStrategies.HighNodeUtilization; highNodeUtilization != nil {
+		strategy := &HighNodeUtilizationPolicy{Enabled: true}
+		nodeThresholds := &strategy.Params.NodeResourceUtilizationThresholds
+		nodeThresholds.NumberOfNodes = int(highNodeUtilization.NumberOfNodes)
+		nodeThresholds.Thresholds.CPU = int(highNodeUtilization.Thresholds.CPU)
+		nodeThresholds.Thresholds.Memory = int(highNodeUtilization.Thresholds.Memory)
+		nodeThresholds.Thresholds.Pods = int(highNodeUtilization.Thresholds.Pods)
+		strategy.Params.PolicyPriorityThreshold = policyPriorityThreshold(highNodeUtilization.PriorityThreshold, spec.PriorityThreshold)
+		policy.Strategies.HighNodeUtilization = strategy
+	}
+	if failedPods := requestedStrategies.RemoveFailedPods; failedPods != nil {
+		strategy := &FailedPodsPolicy{Enabled: true}
+		strategy.Params.FailedPods.Reasons = failedPods.Reasons
+		strategy.Params.FailedPods.IncludingInitContainers = failedPods.IncludingInitContainers
+		strategy.Params.FailedPods.ExcludeOwnerKinds = failedPods.ExcludeOwnerKinds
+		strategy.Params.FailedPods.MinPodLifetimeSeconds = failedPods.MinPodLifetimeSeconds
+		strategy.Params.Namespaces = policyNamespaces(failedPods.Namespaces)
+		strategy.Params.PolicyPriorityThreshold = policyPriorityThreshold(failedPods.PriorityThreshold, spec.PriorityThreshold)
+		policy.Strategies.RemoveFailedPods = strategy
 	}
-	policyContent, err := yaml.Marshal(&policy)
+	return policy
+}
+
+// policyNamespaces returns the namespaces filter of a strategy in the policy, nil when the strategy applies to
+// every namespace
+func policyNamespaces(namespaces *deschedulerv1beta1.Namespaces) *PolicyNamespaces {
+	if namespaces == nil || (len(namespaces.Include) == 0 && len(namespaces.Exclude) == 0) {
+		return nil
+	}
+	return &PolicyNamespaces{Include: namespaces.Include, Exclude: namespaces.Exclude}
+}
+
+// policyPriorityThreshold returns the priority threshold of a strategy in the policy, the global threshold unless
+// the strategy sets its own
+func policyPriorityThreshold(threshold, global deschedulerv1beta1.PriorityThreshold) PolicyPriorityThreshold {
+	if !threshold.IsSet() {
+		threshold = global
+	}
+	return PolicyPriorityThreshold{
+		ThresholdPriority:          threshold.ThresholdPriority,
+		ThresholdPriorityClassName: threshold.ThresholdPriorityClassName,
+	}
+}
+
+// ParsePolicy reads a policy.yaml rendered by generateConfigMapString
+func ParsePolicy(policyContent []byte) (*Policy, error) {
+	policy := &Policy{}
+	if err := yaml.Unmarshal(policyContent, policy); err != nil {
+		return nil, fmt.Errorf("error unmarshalling descheduler policy %v", err)
+	}
+	return policy, nil
+}
+
+// policyHash returns the sha256 of the policy rendered for the Descheduler, it is recorded on the jobs
+// so each DeschedulerRun tells which policy it ran with
+func policyHash(descheduler *deschedulerv1beta1.Descheduler) (string, error) {
+	policy, err := generateConfigMapString(effectiveSpec(descheduler))
 	if err != nil {
-		log.Fatalf("error: %v", err)
+		return "", err
 	}
-	return string(policyContent)
+	sum := sha256.Sum256([]byte(policy))
+	return hex.EncodeToString(sum[:]), nil
 }
 
-// CheckIfPropertyChanges checks if there is any chnage in the config map
-func CheckIfPropertyChanges(strategies []deschedulerv1alpha1.Strategy, existingStrategies map[string]string) bool {
+// CheckIfPropertyChanges checks if there is any chnage in the config map, the policy is rendered from the whole spec
+// so the strategies, the priority thresholds and the evictor options are all compared
+func CheckIfPropertyChanges(spec deschedulerv1beta1.DeschedulerSpec, existingStrategies map[string]string) (bool, error) {
 	policyString := existingStrategies["policy.yaml"]
-	policy := &Policy{}
-	// currentPolicyString := "apiVersion: \"descheduler/v1alpha1\"\nkind:  \"DeschedulerPolicy\"\nstrategies:\n" + generateConfigMapString(strategies, policy)
-	currentPolicyString := generateConfigMapString(strategies, *policy)
+	currentPolicyString, err := generateConfigMapString(spec)
+	if err != nil {
+		return false, err
+	}
 	log.Printf("\n%v, \n%v", policyString, currentPolicyString)
-	return policyString == currentPolicyString
+	return policyString == currentPolicyString, nil
 }
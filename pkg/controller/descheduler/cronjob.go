@@ -5,97 +5,198 @@ import (
 	"fmt"
 	"log"
 	"reflect"
+	"strconv"
 	"strings"
 
-	deschedulerv1alpha1 "github.com/skckadiyala/descheduler-operator/pkg/apis/descheduler/v1alpha1"
+	deschedulerv1beta1 "github.com/skckadiyala/descheduler-operator/pkg/apis/descheduler/v1beta1"
 	batch "k8s.io/api/batch/v1"
 	batchv1beta1 "k8s.io/api/batch/v1beta1"
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
-	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 )
 
-// generateDeschedulerJob generates Descheduler job.
-func (r *ReconcileDescheduler) generateDeschedulerJob(Descheduler *deschedulerv1alpha1.Descheduler) error {
+// generateDeschedulerJob generates Descheduler job. An existing cron job is updated in place, only the fields
+// set by the operator are overwritten, it is recreated only when the update is rejected because of an immutable field.
+// It returns the cron job as created or updated, nil while it is deleted to be recreated.
+func (r *ReconcileDescheduler) generateDeschedulerJob(Descheduler *deschedulerv1beta1.Descheduler) (*batchv1beta1.CronJob, error) {
+	dj, err := r.createCronJob(Descheduler)
+	if err != nil {
+		log.Printf(" error while creating job %v", err)
+		return nil, err
+	}
 	// Check if the cron job already exists
 	DeschedulerCronJob := &batchv1beta1.CronJob{}
-	err := r.client.Get(context.TODO(), types.NamespacedName{Name: Descheduler.Name, Namespace: Descheduler.Namespace}, DeschedulerCronJob)
+	err = r.client.Get(context.TODO(), types.NamespacedName{Name: Descheduler.Name, Namespace: Descheduler.Namespace}, DeschedulerCronJob)
 	if err != nil && errors.IsNotFound(err) {
 		// Create Descheduler cronjob
-		dj, err := r.createCronJob(Descheduler)
-		if err != nil {
-			log.Printf(" error while creating job %v", err)
-			return err
-		}
 		log.Printf("Creating a new cron job %s/%s\n", dj.Namespace, dj.Name)
 		err = r.client.Create(context.TODO(), dj)
 		if err != nil {
 			log.Printf(" error while creating cron job %v", err)
-			return err
+			return nil, err
 		}
+		r.recordCreated(Descheduler, "CronJob", dj.Name)
 		// Cronjob created successfully - don't requeue
-		return nil
-	} else if DeschedulerCronJob.Spec.Schedule != Descheduler.Spec.Schedule {
-		// Descheduler schedule mismatch. Let's delete it and in the next reconcilation loop, we will create a new one.
-		log.Printf("Schedule mismatch in cron job. Delete it")
-		err = r.client.Delete(context.TODO(), DeschedulerCronJob, client.PropagationPolicy(metav1.DeletePropagationOrphan))
-		if err != nil {
-			log.Printf("Error while deleting cronjob")
-			return err
+		return dj, nil
+	} else if err != nil {
+		return nil, err
+	} else if err := r.claim(Descheduler, DeschedulerCronJob, "CronJob"); err != nil {
+		return nil, err
+	} else if cronJobUpToDate(DeschedulerCronJob, dj) {
+		return DeschedulerCronJob, nil
+	}
+
+	log.Printf("Schedule, suspend, policy or pod template mismatch in cron job %s/%s. Update it", DeschedulerCronJob.Namespace, DeschedulerCronJob.Name)
+	applyCronJob(DeschedulerCronJob, dj)
+	err = r.client.Update(context.TODO(), DeschedulerCronJob)
+	if err != nil && errors.IsInvalid(err) {
+		// Deleting the cron job would lose its history and the jobs it runs, the spec has to be fixed instead
+		log.Printf("Cron job can't be updated in place %v", err)
+		return nil, fmt.Errorf("cron job %s can't be updated in place: %v", DeschedulerCronJob.Name, err)
+	} else if err != nil {
+		log.Printf("Error while updating cronjob %v", err)
+		return nil, err
+	}
+	r.recordUpdated(Descheduler, "CronJob", DeschedulerCronJob.Name)
+	return DeschedulerCronJob, nil
+}
+
+// cronJobUpToDate checks that every field applyCronJob owns matches the desired cron job: the schedule, suspend, the
+// policy hash and the containers, volumes and settings of the descheduler pod. Fields set by others are ignored.
+func cronJobUpToDate(existing, desired *batchv1beta1.CronJob) bool {
+	desired = desired.DeepCopy()
+	setPodSpecDefaults(&desired.Spec.JobTemplate.Spec.Template.Spec)
+	applied := existing.DeepCopy()
+	applyCronJob(applied, desired)
+	return equality.Semantic.DeepEqual(applied.Spec, existing.Spec)
+}
+
+// applyCronJob copies the fields owned by the operator from desired to existing, fields set by others
+// (defaults, other containers and volumes, labels) are kept
+func applyCronJob(existing, desired *batchv1beta1.CronJob) {
+	existing.Spec.Schedule = desired.Spec.Schedule
+	existing.Spec.Suspend = desired.Spec.Suspend
+	if existing.Spec.JobTemplate.Annotations == nil {
+		existing.Spec.JobTemplate.Annotations = map[string]string{}
+	}
+	for key, value := range desired.Spec.JobTemplate.Annotations {
+		existing.Spec.JobTemplate.Annotations[key] = value
+	}
+	applyPodSpec(&existing.Spec.JobTemplate.Spec.Template.Spec, desired.Spec.JobTemplate.Spec.Template.Spec)
+}
+
+// applyPodSpec copies the containers, volumes and settings of the descheduler pod from desiredPod to existingPod
+func applyPodSpec(existingPod *v1.PodSpec, desiredPod v1.PodSpec) {
+	for _, container := range desiredPod.Containers {
+		if current := findContainer(existingPod.Containers, container.Name); current != nil {
+			current.Image = container.Image
+			current.Command = container.Command
+			current.Resources = container.Resources
+			current.VolumeMounts = container.VolumeMounts
+		} else {
+			existingPod.Containers = append(existingPod.Containers, container)
 		}
-		return r.updateDeschedulerStatus(Descheduler, Updating)
-	} else if !CheckIfFlagsChanged(Descheduler.Spec.Flags, DeschedulerCronJob.Spec.JobTemplate.Spec.
-		Template.Spec.Containers[0].Command) {
-		//By the time we reach here, job would have been created, so no need to check for nil pointers anywhere
-		// till command
-		log.Printf("Flags mismatch for Descheduler. Delete cronjob")
-
-		err = r.client.Delete(context.TODO(), DeschedulerCronJob, client.PropagationPolicy(metav1.DeletePropagationOrphan))
-		if err != nil {
-			log.Printf("Error while deleting cronjob")
-			return err
+	}
+	for _, volume := range desiredPod.Volumes {
+		found := false
+		for i := range existingPod.Volumes {
+			if existingPod.Volumes[i].Name == volume.Name {
+				existingPod.Volumes[i].VolumeSource = volume.VolumeSource
+				found = true
+			}
 		}
-		return r.updateDeschedulerStatus(Descheduler, Updating)
-	} else if Descheduler.Spec.Image != DeschedulerCronJob.Spec.JobTemplate.Spec.Template.Spec.Containers[0].Image {
-		log.Printf("Image mismatch within Descheduler. Delete cronjob")
-		err = r.client.Delete(context.TODO(), DeschedulerCronJob, client.PropagationPolicy(metav1.DeletePropagationOrphan))
-		if err != nil {
-			log.Printf("Error while deleting cronjob")
-			return err
+		if !found {
+			existingPod.Volumes = append(existingPod.Volumes, volume)
+		}
+	}
+	existingPod.PriorityClassName = desiredPod.PriorityClassName
+	existingPod.RestartPolicy = desiredPod.RestartPolicy
+	existingPod.ServiceAccountName = desiredPod.ServiceAccountName
+}
+
+// setPodSpecDefaults sets the defaults the API server sets on the fields applyPodSpec owns, so that a desired pod spec
+// compares equal to the stored one it was applied to
+func setPodSpecDefaults(podSpec *v1.PodSpec) {
+	for i := range podSpec.Volumes {
+		if configMap := podSpec.Volumes[i].ConfigMap; configMap != nil && configMap.DefaultMode == nil {
+			mode := v1.ConfigMapVolumeSourceDefaultMode
+			configMap.DefaultMode = &mode
+		}
+	}
+}
+
+// findContainer returns the container with the given name, or nil
+func findContainer(containers []v1.Container, name string) *v1.Container {
+	for i := range containers {
+		if containers[i].Name == name {
+			return &containers[i]
 		}
-		return r.updateDeschedulerStatus(Descheduler, Updating)
-	} else if err != nil {
-		return err
 	}
 	return nil
 }
 
 // CheckIfFlagsChanged checks if any of the flags changed.
-func CheckIfFlagsChanged(newFlags []deschedulerv1alpha1.Param, oldFlags []string) bool {
-	latestFlags, err := ValidateFlags(newFlags)
+func CheckIfFlagsChanged(descheduler *deschedulerv1beta1.Descheduler, oldCommand []string) bool {
+	latestCommand, err := deschedulerCommand(descheduler)
 	if err != nil {
 		log.Printf("Invalid flags detected")
 		return false
 	}
-	if latestFlags == nil {
-		latestFlags = DeschedulerCommand
-		return reflect.DeepEqual(latestFlags, oldFlags)
+	return reflect.DeepEqual(latestCommand, oldCommand)
+}
+
+// deschedulerCommand returns the command of the descheduler container, DeschedulerCommand followed by
+// the log-level, --dry-run when spec.dryRun is set and the flags of the Descheduler the release accepts
+func deschedulerCommand(descheduler *deschedulerv1beta1.Descheduler) ([]string, error) {
+	flags, err := ValidateFlags(commandFlags(effectiveSpec(descheduler)))
+	if err != nil {
+		return nil, err
+	}
+	logVerbosity := deschedulerv1beta1.DefaultLogVerbosity
+	if descheduler.Spec.LogVerbosity != nil {
+		logVerbosity = *descheduler.Spec.LogVerbosity
 	}
-	return reflect.DeepEqual(latestFlags, oldFlags)
+	command := make([]string, 0, len(DeschedulerCommand)+3+len(flags))
+	command = append(command, DeschedulerCommand...)
+	command = append(command, "--v", strconv.Itoa(int(logVerbosity)))
+	if descheduler.Spec.DryRun {
+		command = append(command, "--"+deschedulerv1beta1.FlagDryRun)
+	}
+	return append(command, flags...), nil
+}
+
+// oneShotCommand returns the command of a descheduler job, deschedulerCommand without the descheduling-interval
+// flag, a job looping forever never completes
+func oneShotCommand(descheduler *deschedulerv1beta1.Descheduler) ([]string, error) {
+	command, err := deschedulerCommand(descheduler)
+	if err != nil {
+		return nil, err
+	}
+	oneShot := make([]string, 0, len(command))
+	for i := 0; i < len(command); i++ {
+		if command[i] == "--"+deschedulerv1beta1.FlagDeschedulingInterval {
+			// Skip the value too
+			i++
+			continue
+		}
+		oneShot = append(oneShot, command[i])
+	}
+	return oneShot, nil
 }
 
 // ValidateFlags validates flags for descheduler. We don't validate the values here in descheduler operator.
-func ValidateFlags(flags []deschedulerv1alpha1.Param) ([]string, error) {
+func ValidateFlags(flags []deschedulerv1beta1.Param) ([]string, error) {
 	log.Printf("Validating descheduler flags")
 	if len(flags) == 0 {
 		return nil, nil
 	}
 	deschedulerFlags := make([]string, 0)
-	validFlags := []string{"descheduling-interval", "dry-run", "node-selector"}
+	validFlags := deschedulerv1beta1.AllowedFlags
 	// deschedulerFlags = DeschedulerCommand
 	// log.Printf("deschedulerFlags %v ", deschedulerFlags)
 	for _, flag := range flags {
@@ -116,77 +217,27 @@ func ValidateFlags(flags []deschedulerv1alpha1.Param) ([]string, error) {
 }
 
 // createCronJob creates a descheduler job.
-func (r *ReconcileDescheduler) createCronJob(descheduler *deschedulerv1alpha1.Descheduler) (*batchv1beta1.CronJob, error) {
+func (r *ReconcileDescheduler) createCronJob(descheduler *deschedulerv1beta1.Descheduler) (*batchv1beta1.CronJob, error) {
 	log.Printf("Creating descheduler job")
-	// ttl := int32(100)
-	// TTLSecondsAfterFinished: &ttl,
-	flags, err := ValidateFlags(descheduler.Spec.Flags)
+	template, err := deschedulerJobTemplate(descheduler)
 	if err != nil {
 		return nil, err
 	}
-	if len(descheduler.Spec.Image) == 0 {
-		// Set the default image here
-		descheduler.Spec.Image = DefaultImage // No need to update the CR here making it opaque to end-user
-	}
-
-	flags = append(DeschedulerCommand, flags...)
+	suspend := descheduler.Spec.Suspend
 
 	job := &batchv1beta1.CronJob{
 		TypeMeta: metav1.TypeMeta{
 			Kind:       "CronJob",
-			APIVersion: batch.SchemeGroupVersion.String(),
+			APIVersion: batchv1beta1.SchemeGroupVersion.String(),
 		},
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      descheduler.Name,
 			Namespace: descheduler.Namespace,
 		},
 		Spec: batchv1beta1.CronJobSpec{
-			Schedule: descheduler.Spec.Schedule,
-			JobTemplate: batchv1beta1.JobTemplateSpec{
-				ObjectMeta: metav1.ObjectMeta{
-					Name: "descheduler-job-spec",
-				},
-				Spec: batch.JobSpec{
-					// TTLSecondsAfterFinished: &ttl,
-					Template: v1.PodTemplateSpec{
-						Spec: v1.PodSpec{
-							Volumes: []v1.Volume{{
-								Name: "policy-volume",
-								VolumeSource: v1.VolumeSource{
-									ConfigMap: &v1.ConfigMapVolumeSource{
-										LocalObjectReference: v1.LocalObjectReference{
-											Name: descheduler.Name,
-										},
-									},
-								},
-							},
-							},
-							PriorityClassName: "system-cluster-critical",
-							RestartPolicy:     "Never",
-							Containers: []v1.Container{{
-								Name:  "descheduler-axway",
-								Image: descheduler.Spec.Image,
-								Resources: v1.ResourceRequirements{
-									Limits: v1.ResourceList{
-										v1.ResourceCPU:    resource.MustParse("100m"),
-										v1.ResourceMemory: resource.MustParse("500Mi"),
-									},
-									Requests: v1.ResourceList{
-										v1.ResourceCPU:    resource.MustParse("100m"),
-										v1.ResourceMemory: resource.MustParse("500Mi"),
-									},
-								},
-								Command: flags,
-								VolumeMounts: []v1.VolumeMount{{
-									MountPath: "/policy-dir",
-									Name:      "policy-volume",
-								}},
-							}},
-							ServiceAccountName: "descheduler-operator", // TODO: This is hardcoded as of now, find a way to reference it from rbac.yaml.
-						},
-					},
-				},
-			},
+			Schedule:    descheduler.Spec.Schedule,
+			Suspend:     &suspend,
+			JobTemplate: template,
 		},
 	}
 	err = controllerutil.SetControllerReference(descheduler, job, r.scheme)
@@ -195,3 +246,71 @@ func (r *ReconcileDescheduler) createCronJob(descheduler *deschedulerv1alpha1.De
 	}
 	return job, nil
 }
+
+// deschedulerJobTemplate returns the template of the jobs running descheduler once: the jobs of the cron job,
+// the job of Job mode and the run-now jobs
+func deschedulerJobTemplate(descheduler *deschedulerv1beta1.Descheduler) (batchv1beta1.JobTemplateSpec, error) {
+	// ttl := int32(100)
+	// TTLSecondsAfterFinished: &ttl,
+	command, err := oneShotCommand(descheduler)
+	if err != nil {
+		return batchv1beta1.JobTemplateSpec{}, err
+	}
+	hash, err := policyHash(descheduler)
+	if err != nil {
+		return batchv1beta1.JobTemplateSpec{}, err
+	}
+	return batchv1beta1.JobTemplateSpec{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "descheduler-job-spec",
+			Annotations: map[string]string{
+				PolicyHashAnnotation: hash,
+			},
+		},
+		Spec: batch.JobSpec{
+			// TTLSecondsAfterFinished: &ttl,
+			Template: deschedulerPodTemplate(descheduler, command, v1.RestartPolicyNever),
+		},
+	}, nil
+}
+
+// deschedulerPodTemplate returns the template of the descheduler pod, mounting the policy config map of the Descheduler
+func deschedulerPodTemplate(descheduler *deschedulerv1beta1.Descheduler, command []string, restartPolicy v1.RestartPolicy) v1.PodTemplateSpec {
+	return v1.PodTemplateSpec{
+		Spec: v1.PodSpec{
+			Volumes: []v1.Volume{{
+				Name: "policy-volume",
+				VolumeSource: v1.VolumeSource{
+					ConfigMap: &v1.ConfigMapVolumeSource{
+						LocalObjectReference: v1.LocalObjectReference{
+							Name: descheduler.Name,
+						},
+					},
+				},
+			},
+			},
+			PriorityClassName: "system-cluster-critical",
+			RestartPolicy:     restartPolicy,
+			Containers: []v1.Container{{
+				Name:  DeschedulerContainerName,
+				Image: deschedulerImage(descheduler),
+				Resources: v1.ResourceRequirements{
+					Limits: v1.ResourceList{
+						v1.ResourceCPU:    resource.MustParse("100m"),
+						v1.ResourceMemory: resource.MustParse("500Mi"),
+					},
+					Requests: v1.ResourceList{
+						v1.ResourceCPU:    resource.MustParse("100m"),
+						v1.ResourceMemory: resource.MustParse("500Mi"),
+					},
+				},
+				Command: command,
+				VolumeMounts: []v1.VolumeMount{{
+					MountPath: "/policy-dir",
+					Name:      "policy-volume",
+				}},
+			}},
+			ServiceAccountName: "descheduler-operator", // TODO: This is hardcoded as of now, find a way to reference it from rbac.yaml.
+		},
+	}
+}
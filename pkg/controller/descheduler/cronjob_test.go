@@ -0,0 +1,170 @@
+package descheduler
+
+import (
+	"strings"
+	"testing"
+
+	deschedulerv1beta1 "github.com/skckadiyala/descheduler-operator/pkg/apis/descheduler/v1beta1"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1beta1 "k8s.io/api/batch/v1beta1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func testDescheduler() *deschedulerv1beta1.Descheduler {
+	d := &deschedulerv1beta1.Descheduler{
+		ObjectMeta: metav1.ObjectMeta{Name: "example-descheduler", Namespace: "kube-system"},
+		Spec: deschedulerv1beta1.DeschedulerSpec{
+			Strategies: deschedulerv1beta1.DeschedulerStrategies{
+				RemoveDuplicates: &deschedulerv1beta1.RemoveDuplicatesStrategy{},
+			},
+		},
+	}
+	d.Default()
+	return d
+}
+
+// storedCronJob returns the desired cron job with the defaults the API server sets
+func storedCronJob(desired *batchv1beta1.CronJob) *batchv1beta1.CronJob {
+	stored := desired.DeepCopy()
+	setPodSpecDefaults(&stored.Spec.JobTemplate.Spec.Template.Spec)
+	stored.Spec.JobTemplate.Spec.Template.Spec.DNSPolicy = v1.DNSClusterFirst
+	stored.Spec.JobTemplate.Spec.Template.Spec.Containers[0].ImagePullPolicy = v1.PullIfNotPresent
+	return stored
+}
+
+func TestCronJobUpToDate(t *testing.T) {
+	r := &ReconcileDescheduler{scheme: runtime.NewScheme()}
+	desired, err := r.createCronJob(testDescheduler())
+	if err != nil {
+		t.Fatalf("createCronJob: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		edit     func(cronJob *batchv1beta1.CronJob)
+		upToDate bool
+	}{
+		{"unchanged", func(cronJob *batchv1beta1.CronJob) {}, true},
+		{"fields the operator doesn't own", func(cronJob *batchv1beta1.CronJob) {
+			cronJob.Labels = map[string]string{"team": "platform"}
+			cronJob.Spec.JobTemplate.Annotations["team"] = "platform"
+			cronJob.Spec.JobTemplate.Spec.Template.Spec.NodeSelector = map[string]string{"pool": "system"}
+		}, true},
+		{"schedule", func(cronJob *batchv1beta1.CronJob) { cronJob.Spec.Schedule = "0 * * * *" }, false},
+		{"suspend", func(cronJob *batchv1beta1.CronJob) {
+			suspend := true
+			cronJob.Spec.Suspend = &suspend
+		}, false},
+		{"policy hash", func(cronJob *batchv1beta1.CronJob) {
+			cronJob.Spec.JobTemplate.Annotations[PolicyHashAnnotation] = "outdated"
+		}, false},
+		{"image", func(cronJob *batchv1beta1.CronJob) {
+			cronJob.Spec.JobTemplate.Spec.Template.Spec.Containers[0].Image = "descheduler:latest"
+		}, false},
+		{"command", func(cronJob *batchv1beta1.CronJob) {
+			container := &cronJob.Spec.JobTemplate.Spec.Template.Spec.Containers[0]
+			container.Command = append(container.Command, "--dry-run")
+		}, false},
+		{"resources", func(cronJob *batchv1beta1.CronJob) {
+			cronJob.Spec.JobTemplate.Spec.Template.Spec.Containers[0].Resources.Limits[v1.ResourceMemory] = resource.MustParse("1Gi")
+		}, false},
+		{"volume mounts", func(cronJob *batchv1beta1.CronJob) {
+			cronJob.Spec.JobTemplate.Spec.Template.Spec.Containers[0].VolumeMounts[0].MountPath = "/etc/policy"
+		}, false},
+		{"volumes", func(cronJob *batchv1beta1.CronJob) {
+			cronJob.Spec.JobTemplate.Spec.Template.Spec.Volumes[0].ConfigMap.Name = "other-policy"
+		}, false},
+		{"service account", func(cronJob *batchv1beta1.CronJob) {
+			cronJob.Spec.JobTemplate.Spec.Template.Spec.ServiceAccountName = "default"
+		}, false},
+		{"priority class", func(cronJob *batchv1beta1.CronJob) {
+			cronJob.Spec.JobTemplate.Spec.Template.Spec.PriorityClassName = ""
+		}, false},
+		{"descheduler container removed", func(cronJob *batchv1beta1.CronJob) {
+			cronJob.Spec.JobTemplate.Spec.Template.Spec.Containers = []v1.Container{{Name: "sidecar", Image: "busybox"}}
+		}, false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			stored := storedCronJob(desired)
+			test.edit(stored)
+			if upToDate := cronJobUpToDate(stored, desired); upToDate != test.upToDate {
+				t.Errorf("expected up to date %v, got %v", test.upToDate, upToDate)
+			}
+			if !test.upToDate {
+				applyCronJob(stored, desired)
+				// The API server defaults the update
+				setPodSpecDefaults(&stored.Spec.JobTemplate.Spec.Template.Spec)
+				if !cronJobUpToDate(stored, desired) {
+					t.Error("cron job still drifted after applying the desired one")
+				}
+			}
+		})
+	}
+}
+
+func TestDeploymentUpToDate(t *testing.T) {
+	r := &ReconcileDescheduler{scheme: runtime.NewScheme()}
+	descheduler := testDescheduler()
+	descheduler.Spec.Mode = deschedulerv1beta1.ModeDeployment
+	descheduler.Spec.Flags = []deschedulerv1beta1.Param{{Name: deschedulerv1beta1.FlagDeschedulingInterval, Value: "5m"}}
+	desired, err := r.createDeployment(descheduler)
+	if err != nil {
+		t.Fatalf("createDeployment: %v", err)
+	}
+	stored := func() *appsv1.Deployment {
+		deployment := desired.DeepCopy()
+		setPodSpecDefaults(&deployment.Spec.Template.Spec)
+		return deployment
+	}
+
+	if !deploymentUpToDate(stored(), desired) {
+		t.Error("stored deployment should be up to date")
+	}
+	for name, edit := range map[string]func(*appsv1.Deployment){
+		"replicas":        func(d *appsv1.Deployment) { *d.Spec.Replicas = 2 },
+		"strategy":        func(d *appsv1.Deployment) { d.Spec.Strategy.Type = appsv1.RollingUpdateDeploymentStrategyType },
+		"resources":       func(d *appsv1.Deployment) { d.Spec.Template.Spec.Containers[0].Resources = v1.ResourceRequirements{} },
+		"service account": func(d *appsv1.Deployment) { d.Spec.Template.Spec.ServiceAccountName = "default" },
+		"volume":          func(d *appsv1.Deployment) { d.Spec.Template.Spec.Volumes[0].ConfigMap = nil },
+		"template label":  func(d *appsv1.Deployment) { d.Spec.Template.Labels[deschedulerv1beta1.DeschedulerLabel] = "other" },
+	} {
+		deployment := stored()
+		edit(deployment)
+		if deploymentUpToDate(deployment, desired) {
+			t.Errorf("%s: drift not detected", name)
+		}
+	}
+}
+
+func TestNodeSelector(t *testing.T) {
+	for image, flagPassed := range map[string]bool{
+		"k8s.gcr.io/descheduler/descheduler:v0.26.0": true,
+		"k8s.gcr.io/descheduler/descheduler:v0.27.0": false,
+		"k8s.gcr.io/descheduler/descheduler:latest":  true,
+	} {
+		descheduler := testDescheduler()
+		descheduler.Spec.Image = image
+		descheduler.Spec.Flags = []deschedulerv1beta1.Param{{Name: deschedulerv1beta1.FlagNodeSelector, Value: "pool=workers"}}
+		command, err := deschedulerCommand(descheduler)
+		if err != nil {
+			t.Fatalf("%s: deschedulerCommand: %v", image, err)
+		}
+		if passed := strings.Contains(strings.Join(command, " "), "--node-selector pool=workers"); passed != flagPassed {
+			t.Errorf("%s: expected the node-selector flag passed %v, got command %v", image, flagPassed, command)
+		}
+		policy, err := generateConfigMapString(descheduler.Spec)
+		if err != nil {
+			t.Fatalf("%s: generateConfigMapString: %v", image, err)
+		}
+		if !strings.Contains(policy, "nodeSelector: pool=workers\n") {
+			t.Errorf("%s: expected the node selector in the policy, got\n%s", image, policy)
+		}
+		if unsupported, _ := UnsupportedSettings(descheduler.Spec); len(unsupported) != 0 {
+			t.Errorf("%s: expected the node selector supported, got %v", image, unsupported)
+		}
+	}
+}
@@ -0,0 +1,143 @@
+package descheduler
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	deschedulerv1beta1 "github.com/skckadiyala/descheduler-operator/pkg/apis/descheduler/v1beta1"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// generateDeschedulerDeployment generates the Deployment running descheduler in Deployment mode. Like the cron job,
+// an existing deployment is updated in place and recreated only when the update is rejected.
+func (r *ReconcileDescheduler) generateDeschedulerDeployment(descheduler *deschedulerv1beta1.Descheduler) error {
+	desired, err := r.createDeployment(descheduler)
+	if err != nil {
+		log.Printf(" error while creating deployment %v", err)
+		return err
+	}
+	deployment := &appsv1.Deployment{}
+	err = r.client.Get(context.TODO(), types.NamespacedName{Name: descheduler.Name, Namespace: descheduler.Namespace}, deployment)
+	if err != nil && errors.IsNotFound(err) {
+		log.Printf("Creating a new deployment %s/%s\n", desired.Namespace, desired.Name)
+		err = r.client.Create(context.TODO(), desired)
+		if err != nil {
+			log.Printf(" error while creating deployment %v", err)
+			return err
+		}
+		r.recordCreated(descheduler, "Deployment", desired.Name)
+		return nil
+	} else if err != nil {
+		return err
+	} else if err := r.claim(descheduler, deployment, "Deployment"); err != nil {
+		return err
+	} else if deploymentUpToDate(deployment, desired) {
+		return nil
+	}
+
+	log.Printf("Replicas, policy or pod template mismatch in deployment %s/%s. Update it", deployment.Namespace, deployment.Name)
+	applyDeployment(deployment, desired)
+	err = r.client.Update(context.TODO(), deployment)
+	if err != nil && errors.IsInvalid(err) {
+		log.Printf("Deployment can't be updated in place, delete it %v", err)
+		err = r.client.Delete(context.TODO(), deployment, client.PropagationPolicy(metav1.DeletePropagationBackground))
+		if err != nil {
+			log.Printf("Error while deleting deployment")
+			return err
+		}
+		descheduler.Status.Phase = Updating
+		return nil
+	} else if err != nil {
+		log.Printf("Error while updating deployment %v", err)
+		return err
+	}
+	r.recordUpdated(descheduler, "Deployment", deployment.Name)
+	return nil
+}
+
+// deploymentUpToDate checks that every field applyDeployment owns matches the desired deployment: the replicas, the
+// strategy, the labels and annotations of the pod template and the containers, volumes and settings of the pod
+func deploymentUpToDate(existing, desired *appsv1.Deployment) bool {
+	desired = desired.DeepCopy()
+	setPodSpecDefaults(&desired.Spec.Template.Spec)
+	applied := existing.DeepCopy()
+	applyDeployment(applied, desired)
+	return equality.Semantic.DeepEqual(applied.Spec, existing.Spec)
+}
+
+// applyDeployment copies the fields owned by the operator from desired to existing
+func applyDeployment(existing, desired *appsv1.Deployment) {
+	existing.Spec.Replicas = desired.Spec.Replicas
+	existing.Spec.Strategy = desired.Spec.Strategy
+	if existing.Spec.Template.Labels == nil {
+		existing.Spec.Template.Labels = map[string]string{}
+	}
+	for key, value := range desired.Spec.Template.Labels {
+		existing.Spec.Template.Labels[key] = value
+	}
+	if existing.Spec.Template.Annotations == nil {
+		existing.Spec.Template.Annotations = map[string]string{}
+	}
+	for key, value := range desired.Spec.Template.Annotations {
+		existing.Spec.Template.Annotations[key] = value
+	}
+	applyPodSpec(&existing.Spec.Template.Spec, desired.Spec.Template.Spec)
+}
+
+// deploymentReplicas returns 1, or 0 when the Descheduler is suspended
+func deploymentReplicas(descheduler *deschedulerv1beta1.Descheduler) int32 {
+	if descheduler.Spec.Suspend {
+		return 0
+	}
+	return 1
+}
+
+// createDeployment creates the descheduler deployment. Its pods run descheduler with the descheduling-interval flag
+// and are rolled when the policy changes, descheduler reads it only on start.
+func (r *ReconcileDescheduler) createDeployment(descheduler *deschedulerv1beta1.Descheduler) (*appsv1.Deployment, error) {
+	command, err := deschedulerCommand(descheduler)
+	if err != nil {
+		return nil, err
+	}
+	hash, err := policyHash(descheduler)
+	if err != nil {
+		return nil, err
+	}
+	replicas := deploymentReplicas(descheduler)
+	labels := map[string]string{deschedulerv1beta1.DeschedulerLabel: descheduler.Name}
+
+	template := deschedulerPodTemplate(descheduler, command, v1.RestartPolicyAlways)
+	template.Labels = labels
+	template.Annotations = map[string]string{PolicyHashAnnotation: hash}
+	deployment := &appsv1.Deployment{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Deployment",
+			APIVersion: appsv1.SchemeGroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      descheduler.Name,
+			Namespace: descheduler.Namespace,
+			Labels:    labels,
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Selector: &metav1.LabelSelector{MatchLabels: labels},
+			// Two descheduler instances would evict the same pods, stop the old pod before starting the new one
+			Strategy: appsv1.DeploymentStrategy{Type: appsv1.RecreateDeploymentStrategyType},
+			Template: template,
+		},
+	}
+	err = controllerutil.SetControllerReference(descheduler, deployment, r.scheme)
+	if err != nil {
+		return nil, fmt.Errorf("error setting owner references %v", err)
+	}
+	return deployment, nil
+}
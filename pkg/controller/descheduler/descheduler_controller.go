@@ -3,13 +3,22 @@ package descheduler
 import (
 	"context"
 	"fmt"
-	"log"
+	"reflect"
 	"strings"
 
-	deschedulerv1alpha1 "github.com/skckadiyala/descheduler-operator/pkg/apis/descheduler/v1alpha1"
+	deschedulerv1beta1 "github.com/skckadiyala/descheduler-operator/pkg/apis/descheduler/v1beta1"
+	appsv1 "k8s.io/api/apps/v1"
+	batch "k8s.io/api/batch/v1"
+	batchv1beta1 "k8s.io/api/batch/v1beta1"
 	corev1 "k8s.io/api/core/v1"
+	schedulingv1beta1 "k8s.io/api/scheduling/v1beta1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
@@ -22,17 +31,25 @@ import (
 
 var logfmt = logf.Log.WithName("controller_descheduler")
 
+// ReasonDefaultsPersisted is the reason of the event recorded when the operator stores defaults the defaulting
+// webhook didn't set
+const ReasonDefaultsPersisted = "DefaultsPersisted"
+
 const (
 	Running      = "RunningPhase"
 	Updating     = "UpdatingPhase"
-	DefaultImage = "skckadiyala/descheduler:v0.9.0"
+	DefaultImage = deschedulerv1beta1.DefaultImage
 )
 
-// array of valid strategies
-var validStrategies = []string{"duplicates", "interpodantiaffinity", "lownodeutilization", "nodeaffinity"}
+// DeschedulerCommand provides descheduler command with policyconfigfile mounted as volume, the log-level
+// set in the Descheduler is appended to it
+var DeschedulerCommand = []string{"/bin/descheduler", "--policy-config-file", "/policy-dir/policy.yaml"}
+
+// DeschedulerContainerName is the name of the descheduler container in the cron job
+const DeschedulerContainerName = "descheduler-axway"
 
-// DeschedulerCommand provides descheduler command with policyconfigfile mounted as volume and log-level
-var DeschedulerCommand = []string{"/bin/descheduler", "--policy-config-file", "/policy-dir/policy.yaml", "--v", "5"}
+// PolicyHashAnnotation is set on the jobs of the cron job with the sha256 of the policy they run with
+const PolicyHashAnnotation = "descheduler.axway.com/policy-hash"
 
 /**
 * USER ACTION REQUIRED: This is a scaffold file intended for the user to modify with their own Controller
@@ -47,7 +64,12 @@ func Add(mgr manager.Manager) error {
 
 // newReconciler returns a new reconcile.Reconciler
 func newReconciler(mgr manager.Manager) reconcile.Reconciler {
-	return &ReconcileDescheduler{client: mgr.GetClient(), scheme: mgr.GetScheme()}
+	return &ReconcileDescheduler{
+		client:    mgr.GetClient(),
+		scheme:    mgr.GetScheme(),
+		recorder:  mgr.GetRecorder("descheduler-controller"),
+		clientset: kubernetes.NewForConfigOrDie(mgr.GetConfig()),
+	}
 }
 
 // add adds a new Controller to mgr with r as the reconcile.Reconciler
@@ -59,16 +81,58 @@ func add(mgr manager.Manager, r reconcile.Reconciler) error {
 	}
 
 	// Watch for changes to primary resource Descheduler
-	err = c.Watch(&source.Kind{Type: &deschedulerv1alpha1.Descheduler{}}, &handler.EnqueueRequestForObject{})
+	err = c.Watch(&source.Kind{Type: &deschedulerv1beta1.Descheduler{}}, &handler.EnqueueRequestForObject{})
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to secondary resources ConfigMap, CronJob and Deployment and requeue the owner Descheduler,
+	// so out-of-band edits are reverted
+	for _, ownedType := range []runtime.Object{&corev1.ConfigMap{}, &batchv1beta1.CronJob{}, &appsv1.Deployment{}} {
+		err = c.Watch(&source.Kind{Type: ownedType}, &handler.EnqueueRequestForOwner{
+			IsController: true,
+			OwnerType:    &deschedulerv1beta1.Descheduler{},
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	// Jobs are owned by the CronJob or the Descheduler, requeue the Descheduler to follow their outcome
+	err = c.Watch(&source.Kind{Type: &batch.Job{}}, &handler.EnqueueRequestsFromMapFunc{
+		ToRequests: handler.ToRequestsFunc(func(object handler.MapObject) []reconcile.Request {
+			return jobToDescheduler(mgr.GetClient(), object.Meta)
+		}),
+	})
 	if err != nil {
 		return err
 	}
 
-	// TODO(user): Modify this to be the types you create that are owned by the primary resource
-	// Watch for changes to secondary resource Pods and requeue the owner Descheduler
-	err = c.Watch(&source.Kind{Type: &corev1.Pod{}}, &handler.EnqueueRequestForOwner{
-		IsController: true,
-		OwnerType:    &deschedulerv1alpha1.Descheduler{},
+	// Requeue the Deschedulers whose spec.policy refers to a ConfigMap when it changes, to roll out its policy
+	err = c.Watch(&source.Kind{Type: &corev1.ConfigMap{}}, &handler.EnqueueRequestsFromMapFunc{
+		ToRequests: handler.ToRequestsFunc(func(object handler.MapObject) []reconcile.Request {
+			return policyConfigMapToDeschedulers(mgr.GetClient(), object.Meta)
+		}),
+	})
+	if err != nil {
+		return err
+	}
+
+	// Requeue the Deschedulers whose priority thresholds refer to a PriorityClass when it is created or deleted
+	err = c.Watch(&source.Kind{Type: &schedulingv1beta1.PriorityClass{}}, &handler.EnqueueRequestsFromMapFunc{
+		ToRequests: handler.ToRequestsFunc(func(object handler.MapObject) []reconcile.Request {
+			return priorityClassToDeschedulers(mgr.GetClient(), object.Meta)
+		}),
+	})
+	if err != nil {
+		return err
+	}
+
+	// Pods of a job stuck pulling its image don't change the job status, requeue the Descheduler owning their job
+	err = c.Watch(&source.Kind{Type: &corev1.Pod{}}, &handler.EnqueueRequestsFromMapFunc{
+		ToRequests: handler.ToRequestsFunc(func(object handler.MapObject) []reconcile.Request {
+			return podToDescheduler(mgr.GetClient(), object.Meta)
+		}),
 	})
 	if err != nil {
 		return err
@@ -77,6 +141,39 @@ func add(mgr manager.Manager, r reconcile.Reconciler) error {
 	return nil
 }
 
+// podToDescheduler returns the Descheduler owning the job of the pod, if any
+func podToDescheduler(c client.Client, pod metav1.Object) []reconcile.Request {
+	owner := metav1.GetControllerOf(pod)
+	if owner == nil || owner.Kind != "Job" {
+		return nil
+	}
+	job := &batch.Job{}
+	if err := c.Get(context.TODO(), types.NamespacedName{Name: owner.Name, Namespace: pod.GetNamespace()}, job); err != nil {
+		return nil
+	}
+	return jobToDescheduler(c, job)
+}
+
+// jobToDescheduler returns the Descheduler owning the job, directly or through the CronJob that created it, if any
+func jobToDescheduler(c client.Client, job metav1.Object) []reconcile.Request {
+	descheduler := metav1.GetControllerOf(job)
+	if descheduler != nil && descheduler.Kind == "CronJob" {
+		cronJob := &batchv1beta1.CronJob{}
+		if err := c.Get(context.TODO(), types.NamespacedName{Name: descheduler.Name, Namespace: job.GetNamespace()}, cronJob); err != nil {
+			return nil
+		}
+		descheduler = metav1.GetControllerOf(cronJob)
+	}
+	if descheduler == nil || descheduler.Kind != "Descheduler" {
+		return nil
+	}
+	// CronJobs created before v1beta1 reference their owner with the v1alpha1 version
+	if gv, err := schema.ParseGroupVersion(descheduler.APIVersion); err != nil || gv.Group != deschedulerv1beta1.SchemeGroupVersion.Group {
+		return nil
+	}
+	return []reconcile.Request{{NamespacedName: types.NamespacedName{Name: descheduler.Name, Namespace: job.GetNamespace()}}}
+}
+
 // blank assignment to verify that ReconcileDescheduler implements reconcile.Reconciler
 var _ reconcile.Reconciler = &ReconcileDescheduler{}
 
@@ -84,8 +181,11 @@ var _ reconcile.Reconciler = &ReconcileDescheduler{}
 type ReconcileDescheduler struct {
 	// This client, initialized using mgr.Client() above, is a split client
 	// that reads objects from the cache and writes to the apiserver
-	client client.Client
-	scheme *runtime.Scheme
+	client   client.Client
+	scheme   *runtime.Scheme
+	recorder record.EventRecorder
+	// clientset reads the logs of descheduler pods, which the split client can't
+	clientset kubernetes.Interface
 }
 
 // Reconcile reads that state of the cluster for a Descheduler object and makes changes based on the state read
@@ -100,7 +200,7 @@ func (r *ReconcileDescheduler) Reconcile(request reconcile.Request) (reconcile.R
 	reqLogger.Info("Reconciling Descheduler")
 
 	// Fetch the Descheduler instance
-	descheduler := &deschedulerv1alpha1.Descheduler{}
+	descheduler := &deschedulerv1beta1.Descheduler{}
 	err := r.client.Get(context.TODO(), request.NamespacedName, descheduler)
 	if err != nil {
 		if errors.IsNotFound(err) {
@@ -115,84 +215,154 @@ func (r *ReconcileDescheduler) Reconcile(request reconcile.Request) (reconcile.R
 		return reconcile.Result{}, err
 	}
 
-	//Descheduler. If descheduler object doesn't have any of the valid fields, return error
-	// immediatly, don't proceed with config map/job creation
+	oldStatus := descheduler.Status.DeepCopy()
 
-	if len(descheduler.Spec.Schedule) == 0 {
-		reqLogger.Info("Deschdeular should have schedule for corn job set")
-		return reconcile.Result{}, err
-	}
+	// Defaults are persisted by the defaulting webhook, apply them for Deschedulers created before it was
+	// installed or while it couldn't be called
+	stored := descheduler.Spec.DeepCopy()
+	descheduler.Default()
 
-	strategiesEnabled := getAllStrategiesEnabled(descheduler.Spec.Strategies)
-	if err := validateStrategies(strategiesEnabled); err != nil {
-		return reconcile.Result{}, err
+	// Descheduler objects are validated by the admission webhook, validate them again in case it is not
+	// installed. If descheduler object isn't valid, return error immediatly, don't proceed with config map/job creation
+	if err := descheduler.Validate(); err != nil {
+		reqLogger.Info("Invalid descheduler", "error", err.Error())
+		setCondition(descheduler, deschedulerv1beta1.ConditionPolicyValid, corev1.ConditionFalse, ReasonInvalidSpec, err.Error())
+		setCondition(descheduler, deschedulerv1beta1.ConditionReady, corev1.ConditionFalse, ReasonInvalidSpec,
+			"descheduler spec is invalid, see the PolicyValid condition")
+		// Don't requeue, fixing the descheduler triggers a new reconcile
+		return reconcile.Result{}, r.updateDeschedulerStatus(descheduler, oldStatus)
+	}
+	// Persist the defaults the webhook didn't, so a new operator release doesn't change what runs
+	if !reflect.DeepEqual(*stored, descheduler.Spec) {
+		reqLogger.Info("Persisting the defaults of the descheduler")
+		r.recorder.Event(descheduler, corev1.EventTypeWarning, ReasonDefaultsPersisted,
+			"Defaults were not set by the defaulting webhook, the operator stored them in the spec")
+		// The update triggers a new reconcile
+		return reconcile.Result{}, r.client.Update(context.TODO(), descheduler)
+	}
+	// spec.policy may refer to a ConfigMap, its policy is loaded and parsed like an embedded one
+	if err := r.resolvePolicy(descheduler); err != nil {
+		invalid, ok := err.(*invalidPolicyError)
+		if !ok {
+			return r.degraded(descheduler, oldStatus, ReasonConfigMapFailed, err)
+		}
+		reqLogger.Info("Invalid descheduler", "error", invalid.message)
+		setCondition(descheduler, deschedulerv1beta1.ConditionPolicyValid, corev1.ConditionFalse, invalid.reason, invalid.message)
+		setCondition(descheduler, deschedulerv1beta1.ConditionReady, corev1.ConditionFalse, invalid.reason,
+			"descheduler spec is invalid, see the PolicyValid condition")
+		// Don't requeue, fixing the policy or creating its ConfigMap triggers a new reconcile
+		return reconcile.Result{}, r.updateDeschedulerStatus(descheduler, oldStatus)
+	}
+	// Priority thresholds may refer to PriorityClasses, descheduler fails to start when one of them is missing
+	missing, err := r.missingPriorityClasses(descheduler)
+	if err != nil {
+		return r.degraded(descheduler, oldStatus, ReasonPriorityClassNotFound, err)
+	}
+	if len(missing) != 0 {
+		message := fmt.Sprintf("thresholdPriorityClassName refers to missing PriorityClasses %s", strings.Join(missing, ", "))
+		reqLogger.Info("Invalid descheduler", "error", message)
+		setCondition(descheduler, deschedulerv1beta1.ConditionPolicyValid, corev1.ConditionFalse, ReasonPriorityClassNotFound, message)
+		setCondition(descheduler, deschedulerv1beta1.ConditionReady, corev1.ConditionFalse, ReasonPriorityClassNotFound,
+			"descheduler spec is invalid, see the PolicyValid condition")
+		// Don't requeue, creating the PriorityClass triggers a new reconcile
+		return reconcile.Result{}, r.updateDeschedulerStatus(descheduler, oldStatus)
+	}
+	setCondition(descheduler, deschedulerv1beta1.ConditionPolicyValid, corev1.ConditionTrue, ReasonValid, "")
+	// Run the default image instead of an image failing to pull
+	fallbackRefused, err := r.updateImageFallback(descheduler)
+	if err != nil {
+		return r.degraded(descheduler, oldStatus, ReasonCronJobFailed, err)
+	}
+	// The descheduler release of the image may not support every setting, its policy would fail to load
+	image := deschedulerImage(descheduler)
+	if unsupported, known := UnsupportedSettings(effectiveSpec(descheduler)); !known {
+		setCondition(descheduler, deschedulerv1beta1.ConditionImageCompatible, corev1.ConditionUnknown, ReasonUnknownImageVersion,
+			fmt.Sprintf("the tag of %s isn't a descheduler release, its settings aren't checked", image))
+	} else if len(unsupported) != 0 {
+		message := fmt.Sprintf("%s: %s", image, strings.Join(unsupported, ", "))
+		reqLogger.Info("Incompatible descheduler", "error", message)
+		setCondition(descheduler, deschedulerv1beta1.ConditionImageCompatible, corev1.ConditionFalse, ReasonUnsupportedSettings, message)
+		setCondition(descheduler, deschedulerv1beta1.ConditionReady, corev1.ConditionFalse, ReasonUnsupportedSettings,
+			"the descheduler image doesn't support the spec, see the ImageCompatible condition")
+		// Don't requeue, changing the image or the spec triggers a new reconcile
+		return reconcile.Result{}, r.updateDeschedulerStatus(descheduler, oldStatus)
+	} else {
+		setCondition(descheduler, deschedulerv1beta1.ConditionImageCompatible, corev1.ConditionTrue, ReasonImageCompatible, "")
+	}
+	if descheduler.Spec.Suspend {
+		setCondition(descheduler, deschedulerv1beta1.ConditionSuspended, corev1.ConditionTrue, ReasonSuspended, "spec.suspend is set")
+	} else {
+		setCondition(descheduler, deschedulerv1beta1.ConditionSuspended, corev1.ConditionFalse, ReasonNotSuspended, "")
 	}
 
-	// Generate Descheduler policy configmap
-
+	// Generate Descheduler policy configmap and the cronjob, deployment or job of the mode, the deployment sets the
+	// phase to Updating when it had to be deleted to be recreated
+	descheduler.Status.Phase = Running
 	if err := r.generateConfigMap(descheduler); err != nil {
-		return reconcile.Result{}, err
+		return r.degraded(descheduler, oldStatus, ReasonConfigMapFailed, err)
 	}
 
-	if err := r.generateDeschedulerJob(descheduler); err != nil {
-		return reconcile.Result{}, err
+	var cronJob *batchv1beta1.CronJob
+	var workloadErr error
+	switch descheduler.Spec.Mode {
+	case deschedulerv1beta1.ModeDeployment:
+		workloadErr = r.generateDeschedulerDeployment(descheduler)
+	case deschedulerv1beta1.ModeJob:
+		workloadErr = r.generateDeschedulerRunJob(descheduler)
+	default:
+		cronJob, workloadErr = r.generateDeschedulerJob(descheduler)
 	}
-	// TODO: Add validation logic to monitor the cronjob failed for n times
-	// with image related issues(eg: ImagePullError etc) and create the cronjob
-	// with default image specified above.
-
-	if descheduler.Status.Phase != Running {
-		if err := r.updateDeschedulerStatus(descheduler, Running); err != nil {
-			return reconcile.Result{}, err
-		}
-		return reconcile.Result{Requeue: true}, nil
+	if workloadErr == nil {
+		// The mode changed, delete the workload of the previous one
+		workloadErr = r.deleteUnusedWorkloads(descheduler)
 	}
-
-	return reconcile.Result{}, nil
-}
-
-func getAllStrategiesEnabled(strategies []deschedulerv1alpha1.Strategy) []string {
-	strategyName := make([]string, 0)
-	for _, strategy := range strategies {
-		strategyName = append(strategyName, strategy.Name)
+	if err := workloadErr; err != nil {
+		setWorkloadCondition(descheduler, corev1.ConditionFalse, ReasonCronJobFailed, err.Error())
+		return r.degraded(descheduler, oldStatus, ReasonCronJobFailed, err)
+	}
+	// Run descheduler now when asked with the run-now annotation
+	if err := r.triggerManualRun(descheduler, cronJob); err != nil {
+		return r.degraded(descheduler, oldStatus, ReasonCronJobFailed, err)
 	}
-	return strategyName
-}
 
-func validateStrategies(strategies []string) error {
-	if len(strategies) == 0 {
-		err := fmt.Errorf("descheduler should have atleast one strategy enabled and if should be one of %v", strings.Join(validStrategies, ","))
-		log.Printf("%v", err)
-		return err
+	cronJobReady, err := r.updateCronJobConditions(descheduler)
+	if err != nil {
+		return r.degraded(descheduler, oldStatus, ReasonCronJobFailed, err)
 	}
-	if len(strategies) > len(validStrategies) {
-		err := fmt.Errorf("descheduler should have more strategyies enabled then supported %v", len(validStrategies))
-		log.Printf("%v", err)
-		return err
+	setCondition(descheduler, deschedulerv1beta1.ConditionConflict, corev1.ConditionFalse, ReasonNoConflict, "")
+	if fallback := descheduler.Status.ImageFallback; fallback != nil {
+		setCondition(descheduler, deschedulerv1beta1.ConditionDegraded, corev1.ConditionTrue, ReasonImageFallback,
+			fmt.Sprintf("image %s failed to pull (%s), running %s until spec.image changes", fallback.FailedImage, fallback.Reason, fallback.Image))
+	} else if len(fallbackRefused) != 0 {
+		setCondition(descheduler, deschedulerv1beta1.ConditionDegraded, corev1.ConditionTrue, ReasonImageFallbackRefused, fallbackRefused)
+	} else {
+		setCondition(descheduler, deschedulerv1beta1.ConditionDegraded, corev1.ConditionFalse, ReasonReconcileSucceeded, "")
 	}
-	invalidStrategies := identifyInvalidStrategies(strategies)
-	if len(invalidStrategies) > 0 {
-		err := fmt.Errorf("expected one of the %v to be enabled but found following invalid strategies %v",
-			strings.Join(validStrategies, ","), strings.Join(invalidStrategies, ","))
 
-		log.Printf("%v", err)
-		return err
+	// Requeue until the recreated configmap and cronjob, deployment or job are in place
+	requeue := !cronJobReady || descheduler.Status.Phase == Updating
+	if requeue {
+		descheduler.Status.Phase = Updating
+		setCondition(descheduler, deschedulerv1beta1.ConditionReady, corev1.ConditionFalse, ReasonUpdating,
+			fmt.Sprintf("waiting for the descheduler config map and %s to be recreated", strings.ToLower(string(descheduler.Spec.Mode))))
+	} else if descheduler.Spec.Mode == deschedulerv1beta1.ModeCronJob {
+		setCondition(descheduler, deschedulerv1beta1.ConditionReady, corev1.ConditionTrue, ReasonCronJobScheduled, "")
+	} else {
+		// Ready tells how descheduler runs, e.g. DeploymentAvailable
+		setCondition(descheduler, deschedulerv1beta1.ConditionReady, corev1.ConditionTrue,
+			descheduler.Status.GetCondition(deschedulerv1beta1.ConditionWorkloadReady).Reason, "")
+	}
+	if err := r.updateDeschedulerStatus(descheduler, oldStatus); err != nil {
+		return reconcile.Result{}, err
 	}
-	return nil
-}
 
-func identifyInvalidStrategies(strategies []string) []string {
-	invalidStrategiesEnabled := make([]string, 0)
-	for _, strategy := range strategies {
-		validStrategyFound := false
-		for _, validStrategy := range validStrategies {
-			if strings.ToUpper(strategy) == strings.ToUpper(validStrategy) {
-				validStrategyFound = true
-			}
-		}
-		if !validStrategyFound {
-			invalidStrategiesEnabled = append(invalidStrategiesEnabled, strategy)
-		}
+	// Reconcile again when the oldest run record expires, nothing else may trigger it before
+	nextExpiry, err := r.enforceRunRetention(descheduler)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	if requeue {
+		return reconcile.Result{Requeue: true}, nil
 	}
-	return invalidStrategiesEnabled
+	return reconcile.Result{RequeueAfter: nextExpiry}, nil
 }
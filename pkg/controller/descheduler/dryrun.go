@@ -0,0 +1,136 @@
+package descheduler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+
+	deschedulerv1beta1 "github.com/skckadiyala/descheduler-operator/pkg/apis/descheduler/v1beta1"
+	batch "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// DryRunReportKey is the key of the dry run report in the dry run ConfigMap
+const DryRunReportKey = "report.json"
+
+// dryRunConfigMapName returns the name of the ConfigMap holding the dry run report of the Descheduler
+func dryRunConfigMapName(descheduler *deschedulerv1beta1.Descheduler) string {
+	return descheduler.Name + "-dry-run"
+}
+
+// isDryRunCommand returns true when the descheduler command runs with --dry-run, set by spec.dryRun or by the dry-run flag
+func isDryRunCommand(command []string) bool {
+	for i, arg := range command {
+		switch arg {
+		case "--" + deschedulerv1beta1.FlagDryRun + "=true":
+			return true
+		case "--" + deschedulerv1beta1.FlagDryRun:
+			// The flags of spec.flags are followed by their value
+			return i+1 == len(command) || command[i+1] != "false"
+		}
+	}
+	return false
+}
+
+// isDryRunJob returns true when the job runs descheduler with --dry-run
+func isDryRunJob(job *batch.Job) bool {
+	container := findContainer(job.Spec.Template.Spec.Containers, DeschedulerContainerName)
+	return container != nil && isDryRunCommand(container.Command)
+}
+
+// updateDryRunReport records the pods the last finished dry run of the Descheduler would have evicted in its status
+// and in its dry run ConfigMap. evictions are the evictions of the runs recorded during this reconcile, logs are
+// read for other runs.
+func (r *ReconcileDescheduler) updateDryRunReport(descheduler *deschedulerv1beta1.Descheduler,
+	evictions map[string]*deschedulerv1beta1.EvictionSummary) error {
+	var lastRun *deschedulerv1beta1.RunSummary
+	for i := range descheduler.Status.Runs {
+		if run := &descheduler.Status.Runs[i]; run.DryRun && runFinished(run) {
+			lastRun = run
+			break
+		}
+	}
+	if lastRun == nil || (descheduler.Status.DryRunReport != nil && descheduler.Status.DryRunReport.Evictions.JobName == lastRun.JobName) {
+		return nil
+	}
+
+	summary, ok := evictions[lastRun.JobName]
+	if !ok {
+		summary = r.summarizeRunEvictions(descheduler.Namespace, lastRun)
+	}
+	report := &deschedulerv1beta1.DryRunReport{
+		CompletionTime: lastRun.CompletionTime,
+		ConfigMapName:  dryRunConfigMapName(descheduler),
+		Evictions:      *summary.DeepCopy(),
+	}
+	record := &deschedulerv1beta1.DeschedulerRun{}
+	err := r.client.Get(context.TODO(), types.NamespacedName{Name: lastRun.JobName, Namespace: descheduler.Namespace}, record)
+	if err != nil && !errors.IsNotFound(err) {
+		return err
+	} else if err == nil {
+		report.PolicyHash = record.Spec.PolicyHash
+	}
+	if err := r.writeDryRunConfigMap(descheduler, report); err != nil {
+		return err
+	}
+
+	// Only the first pods are listed in the status, the ConfigMap has them all
+	if len(report.Evictions.EvictedPods) > MaxEvictedPods {
+		report.Evictions.EvictedPods = report.Evictions.EvictedPods[:MaxEvictedPods]
+	}
+	descheduler.Status.DryRunReport = report
+	if report.Evictions.Error != "" {
+		r.recorder.Eventf(descheduler, v1.EventTypeWarning, "DryRunReportFailed", "Dry run %s can't be reported: %s",
+			lastRun.JobName, report.Evictions.Error)
+		return nil
+	}
+	r.recorder.Eventf(descheduler, v1.EventTypeNormal, "DryRunReported", "Dry run %s would have evicted %d pods, see config map %s",
+		lastRun.JobName, report.Evictions.Total, report.ConfigMapName)
+	return nil
+}
+
+// writeDryRunConfigMap creates or updates the dry run ConfigMap of the Descheduler with the report
+func (r *ReconcileDescheduler) writeDryRunConfigMap(descheduler *deschedulerv1beta1.Descheduler, report *deschedulerv1beta1.DryRunReport) error {
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error encoding dry run report %v", err)
+	}
+	cm := &v1.ConfigMap{}
+	err = r.client.Get(context.TODO(), types.NamespacedName{Name: report.ConfigMapName, Namespace: descheduler.Namespace}, cm)
+	if err != nil && errors.IsNotFound(err) {
+		cm = &v1.ConfigMap{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "ConfigMap",
+				APIVersion: "v1",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      report.ConfigMapName,
+				Namespace: descheduler.Namespace,
+				Labels: map[string]string{
+					deschedulerv1beta1.DeschedulerLabel: descheduler.Name,
+				},
+			},
+			Data: map[string]string{DryRunReportKey: string(data)},
+		}
+		if err := controllerutil.SetControllerReference(descheduler, cm, r.scheme); err != nil {
+			return fmt.Errorf("error setting owner references %v", err)
+		}
+		log.Printf("Creating dry run config map %s/%s", cm.Namespace, cm.Name)
+		return r.client.Create(context.TODO(), cm)
+	} else if err != nil {
+		return err
+	} else if err := r.claim(descheduler, cm, "ConfigMap"); err != nil {
+		return err
+	}
+	if cm.Data == nil {
+		cm.Data = map[string]string{}
+	}
+	cm.Data[DryRunReportKey] = string(data)
+	log.Printf("Updating dry run config map %s/%s", cm.Namespace, cm.Name)
+	return r.client.Update(context.TODO(), cm)
+}
@@ -0,0 +1,39 @@
+package descheduler
+
+import (
+	"fmt"
+
+	deschedulerv1beta1 "github.com/skckadiyala/descheduler-operator/pkg/apis/descheduler/v1beta1"
+	v1 "k8s.io/api/core/v1"
+)
+
+// EventDriftCorrected is the reason of the events recorded when an owned object edited or deleted out of band is restored
+const EventDriftCorrected = "DriftCorrected"
+
+// driftDetected returns true when the spec of the Descheduler didn't change since the last reconcile and the
+// operator isn't recreating its objects, owned objects differing from the spec were then changed out of band
+func driftDetected(descheduler *deschedulerv1beta1.Descheduler) bool {
+	if descheduler.Status.ObservedGeneration == 0 || descheduler.Status.ObservedGeneration != descheduler.Generation {
+		return false
+	}
+	ready := descheduler.Status.GetCondition(deschedulerv1beta1.ConditionReady)
+	return ready == nil || ready.Reason != ReasonUpdating
+}
+
+// recordCreated records the creation of an owned object on the Descheduler
+func (r *ReconcileDescheduler) recordCreated(descheduler *deschedulerv1beta1.Descheduler, kind, name string) {
+	if driftDetected(descheduler) {
+		r.recorder.Eventf(descheduler, v1.EventTypeWarning, EventDriftCorrected, "%s %s was deleted out of band, recreated it", kind, name)
+		return
+	}
+	r.recorder.Eventf(descheduler, v1.EventTypeNormal, fmt.Sprintf("%sCreated", kind), "Created %s %s", kind, name)
+}
+
+// recordUpdated records the update of an owned object on the Descheduler
+func (r *ReconcileDescheduler) recordUpdated(descheduler *deschedulerv1beta1.Descheduler, kind, name string) {
+	if driftDetected(descheduler) {
+		r.recorder.Eventf(descheduler, v1.EventTypeWarning, EventDriftCorrected, "%s %s was edited out of band, restored it", kind, name)
+		return
+	}
+	r.recorder.Eventf(descheduler, v1.EventTypeNormal, fmt.Sprintf("%sUpdated", kind), "Updated %s %s to match the descheduler spec", kind, name)
+}
@@ -0,0 +1,184 @@
+package descheduler
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"log"
+	"regexp"
+	"strings"
+
+	deschedulerv1beta1 "github.com/skckadiyala/descheduler-operator/pkg/apis/descheduler/v1beta1"
+	v1 "k8s.io/api/core/v1"
+)
+
+// MaxEvictedPods is the number of evicted pods listed in the eviction summary of a Descheduler
+const MaxEvictedPods = 50
+
+// MaxRunEvictedPods is the number of evicted pods listed in a DeschedulerRun
+const MaxRunEvictedPods = 1000
+
+// UnknownStrategy is the strategy evicted pods are counted under when the descheduler logs don't tell it
+const UnknownStrategy = "unknown"
+
+var (
+	// Evicted pod: "nginx-5c7588df-x2x7p" (<nil>), logged by descheduler up to v0.9
+	legacyEvictionRegexp = regexp.MustCompile(`Evicted pod: "([^"]+)"`)
+	// "Evicted pod" pod="default/nginx-5c7588df-x2x7p" reason="" strategy="PodLifeTime" node="node1", logged by
+	// descheduler using structured logging, "Evicted pod in dry run mode" with --dry-run
+	structuredEvictionRegexp = regexp.MustCompile(`"Evicted pod(?: in dry run mode)?"(.*)$`)
+	keyValueRegexp           = regexp.MustCompile(`(\w+)="([^"]*)"`)
+	// Processing node: "node1" and evicting pods from node "node1" tell the node the next evictions happen on,
+	// only LowNodeUtilization logs the latter
+	nodeRegexp = regexp.MustCompile(`(Processing node: |evicting pods from node )"([^"]+)"`)
+	// I0315 10:00:00.100000       1 duplicates.go:49] is the klog header telling the source file of a line
+	sourceFileRegexp = regexp.MustCompile(`^[IWEF]\d{4} [\d:.]+\s+\d+ (\w+\.go):\d+\]`)
+)
+
+// legacyStrategySources are the source files of the strategies of descheduler up to v0.9, whose eviction lines
+// don't tell the strategy: the evictions following a line of one of them are counted under its strategy
+var legacyStrategySources = map[string]string{
+	"duplicates.go":         "RemoveDuplicates",
+	"lownodeutilization.go": "LowNodeUtilization",
+	"pod_antiaffinity.go":   "RemovePodsViolatingInterPodAntiAffinity",
+	"node_affinity.go":      "RemovePodsViolatingNodeAffinity",
+}
+
+// parseEvictions reads descheduler logs and returns the pods it evicted
+func parseEvictions(logs io.Reader) ([]deschedulerv1beta1.EvictedPod, error) {
+	var evicted []deschedulerv1beta1.EvictedPod
+	strategy, node := UnknownStrategy, ""
+	scanner := bufio.NewScanner(logs)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if match := structuredEvictionRegexp.FindStringSubmatch(line); match != nil {
+			pod := deschedulerv1beta1.EvictedPod{Strategy: strategy, Node: node}
+			for _, kv := range keyValueRegexp.FindAllStringSubmatch(match[1], -1) {
+				switch kv[1] {
+				case "pod":
+					if parts := strings.SplitN(kv[2], "/", 2); len(parts) == 2 {
+						pod.Namespace, pod.Name = parts[0], parts[1]
+					} else {
+						pod.Name = kv[2]
+					}
+				case "strategy":
+					pod.Strategy = kv[2]
+				case "node":
+					pod.Node = kv[2]
+				}
+			}
+			evicted = append(evicted, pod)
+			continue
+		}
+		if match := legacyEvictionRegexp.FindStringSubmatch(line); match != nil {
+			evicted = append(evicted, deschedulerv1beta1.EvictedPod{Name: match[1], Node: node, Strategy: strategy})
+			continue
+		}
+		if match := nodeRegexp.FindStringSubmatch(line); match != nil {
+			node = match[2]
+			strategy = UnknownStrategy
+			if strings.HasPrefix(match[1], "evicting") {
+				strategy = "LowNodeUtilization"
+			}
+		}
+		if match := sourceFileRegexp.FindStringSubmatch(line); match != nil {
+			if source, ok := legacyStrategySources[match[1]]; ok {
+				strategy = source
+			}
+		}
+	}
+	return evicted, scanner.Err()
+}
+
+// summarizeEvictions counts the evicted pods, only the first maxListed are listed in the summary
+func summarizeEvictions(jobName string, evicted []deschedulerv1beta1.EvictedPod, maxListed int) *deschedulerv1beta1.EvictionSummary {
+	summary := &deschedulerv1beta1.EvictionSummary{JobName: jobName, Total: int32(len(evicted))}
+	for _, pod := range evicted {
+		summary.ByStrategy = incrementCount(summary.ByStrategy, pod.Strategy)
+		if pod.Namespace != "" {
+			summary.ByNamespace = incrementCount(summary.ByNamespace, pod.Namespace)
+		}
+		if pod.Node != "" {
+			summary.ByNode = incrementCount(summary.ByNode, pod.Node)
+		}
+	}
+	if len(evicted) > maxListed {
+		evicted = evicted[:maxListed]
+	}
+	summary.EvictedPods = evicted
+	return summary
+}
+
+// truncateEvictions returns a copy of the summary listing only its first maxListed pods
+func truncateEvictions(summary *deschedulerv1beta1.EvictionSummary, maxListed int) *deschedulerv1beta1.EvictionSummary {
+	summary = summary.DeepCopy()
+	if len(summary.EvictedPods) > maxListed {
+		summary.EvictedPods = summary.EvictedPods[:maxListed]
+	}
+	return summary
+}
+
+func incrementCount(counts map[string]int32, key string) map[string]int32 {
+	if counts == nil {
+		counts = map[string]int32{}
+	}
+	counts[key]++
+	return counts
+}
+
+// updateEvictionSummary records the pods evicted by the last finished run of the Descheduler in its status, dry runs
+// are reported by updateDryRunReport. evictions are the evictions of the runs recorded during this reconcile, logs
+// are read for other runs. Logs that can't be read are not read again, the summary tells the error instead.
+func (r *ReconcileDescheduler) updateEvictionSummary(descheduler *deschedulerv1beta1.Descheduler,
+	evictions map[string]*deschedulerv1beta1.EvictionSummary) {
+	var lastRun *deschedulerv1beta1.RunSummary
+	for i := range descheduler.Status.Runs {
+		if run := &descheduler.Status.Runs[i]; !run.DryRun && runFinished(run) {
+			lastRun = run
+			break
+		}
+	}
+	if lastRun == nil || (descheduler.Status.LastEvictions != nil && descheduler.Status.LastEvictions.JobName == lastRun.JobName) {
+		return
+	}
+
+	summary, ok := evictions[lastRun.JobName]
+	if !ok {
+		summary = r.summarizeRunEvictions(descheduler.Namespace, lastRun)
+	}
+	descheduler.Status.LastEvictions = truncateEvictions(summary, MaxEvictedPods)
+	if summary.Error == "" {
+		lastRunEvictedPods.WithLabelValues(descheduler.Namespace, descheduler.Name).Set(float64(summary.Total))
+	}
+}
+
+// summarizeRunEvictions reads the evictions of a run from the logs of its pod, the summary holds the error when they
+// can't be read, e.g. once the pod was garbage collected
+func (r *ReconcileDescheduler) summarizeRunEvictions(namespace string, run *deschedulerv1beta1.RunSummary) *deschedulerv1beta1.EvictionSummary {
+	evicted, err := r.readEvictions(namespace, run.PodName)
+	if err != nil {
+		log.Printf("%v", err)
+		return &deschedulerv1beta1.EvictionSummary{JobName: run.JobName, Error: err.Error()}
+	}
+	return summarizeEvictions(run.JobName, evicted, MaxRunEvictedPods)
+}
+
+// readEvictions reads the logs of a descheduler pod and returns the pods it evicted
+func (r *ReconcileDescheduler) readEvictions(namespace, podName string) ([]deschedulerv1beta1.EvictedPod, error) {
+	if podName == "" {
+		return nil, fmt.Errorf("no pod to read evictions from")
+	}
+	logs, err := r.clientset.CoreV1().Pods(namespace).GetLogs(podName,
+		&v1.PodLogOptions{Container: DeschedulerContainerName}).Stream()
+	if err != nil {
+		// The pod may have been garbage collected
+		return nil, fmt.Errorf("error while reading logs of pod %s/%s %v", namespace, podName, err)
+	}
+	defer logs.Close()
+	evicted, err := parseEvictions(logs)
+	if err != nil {
+		return nil, fmt.Errorf("error while parsing logs of pod %s/%s %v", namespace, podName, err)
+	}
+	return evicted, nil
+}
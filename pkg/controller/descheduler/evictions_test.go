@@ -0,0 +1,97 @@
+package descheduler
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	deschedulerv1beta1 "github.com/skckadiyala/descheduler-operator/pkg/apis/descheduler/v1beta1"
+)
+
+func TestParseEvictions(t *testing.T) {
+	tests := []struct {
+		log     string
+		evicted []deschedulerv1beta1.EvictedPod
+	}{
+		{
+			// descheduler up to v0.9, the strategy is the one of the source file of the last strategy line
+			log: "legacy.log",
+			evicted: []deschedulerv1beta1.EvictedPod{
+				{Name: "nginx-5c7588df-x2x7p", Node: "node1", Strategy: "RemoveDuplicates"},
+				{Name: "web-6d4cf56db6-8mqtz", Node: "node2", Strategy: "LowNodeUtilization"},
+				{Name: "web-6d4cf56db6-zq2kc", Node: "node2", Strategy: "LowNodeUtilization"},
+				{Name: "batch-7f9c8d-2lmwb", Node: "node3", Strategy: "RemovePodsViolatingNodeAffinity"},
+			},
+		},
+		{
+			// Every strategy of descheduler v0.9, legacy lines never tell the namespace
+			log: "legacy-strategies.log",
+			evicted: []deschedulerv1beta1.EvictedPod{
+				{Name: "nginx-5c7588df-x2x7p", Node: "node1", Strategy: "RemoveDuplicates"},
+				{Name: "web-6d4cf56db6-8mqtz", Node: "node2", Strategy: "LowNodeUtilization"},
+				{Name: "cache-58d7c9b4f-m2k8w", Node: "node1", Strategy: "RemovePodsViolatingInterPodAntiAffinity"},
+				{Name: "batch-7f9c8d-2lmwb", Node: "node3", Strategy: "RemovePodsViolatingNodeAffinity"},
+			},
+		},
+		{
+			log: "structured.log",
+			evicted: []deschedulerv1beta1.EvictedPod{
+				{Name: "nginx-5c7588df-x2x7p", Namespace: "default", Node: "node1", Strategy: "RemoveDuplicates"},
+				{Name: "metrics-server-7d5c8d9f-q8x2n", Namespace: "kube-system", Node: "node2", Strategy: "PodLifeTime"},
+				{Name: "web-6d4cf56db6-8mqtz", Namespace: "default", Node: "node3", Strategy: "LowNodeUtilization"},
+			},
+		},
+		{
+			// Evictions logged before any strategy line, or without a strategy, fall back to unknown
+			log: "unknown-strategy.log",
+			evicted: []deschedulerv1beta1.EvictedPod{
+				{Name: "nginx-5c7588df-x2x7p", Strategy: UnknownStrategy},
+				{Name: "web-6d4cf56db6-8mqtz", Namespace: "default", Strategy: UnknownStrategy},
+				{Name: "web-6d4cf56db6-zq2kc", Node: "node2", Strategy: "LowNodeUtilization"},
+				{Name: "batch-7f9c8d-2lmwb", Node: "node3", Strategy: "RemovePodsViolatingInterPodAntiAffinity"},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.log, func(t *testing.T) {
+			logs, err := os.Open(filepath.Join("testdata", test.log))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer logs.Close()
+			evicted, err := parseEvictions(logs)
+			if err != nil {
+				t.Fatalf("parseEvictions: %v", err)
+			}
+			if !reflect.DeepEqual(evicted, test.evicted) {
+				t.Errorf("expected %+v\ngot      %+v", test.evicted, evicted)
+			}
+		})
+	}
+}
+
+func TestSummarizeEvictions(t *testing.T) {
+	evicted := []deschedulerv1beta1.EvictedPod{
+		{Name: "a", Namespace: "default", Node: "node1", Strategy: "RemoveDuplicates"},
+		{Name: "b", Namespace: "default", Node: "node2", Strategy: "RemoveDuplicates"},
+		{Name: "c", Node: "node2", Strategy: UnknownStrategy},
+	}
+	summary := summarizeEvictions("job", evicted, 2)
+	expected := &deschedulerv1beta1.EvictionSummary{
+		JobName:     "job",
+		Total:       3,
+		ByStrategy:  map[string]int32{"RemoveDuplicates": 2, UnknownStrategy: 1},
+		ByNamespace: map[string]int32{"default": 2},
+		ByNode:      map[string]int32{"node1": 1, "node2": 2},
+		EvictedPods: evicted[:2],
+	}
+	if !reflect.DeepEqual(summary, expected) {
+		t.Errorf("expected %+v\ngot      %+v", expected, summary)
+	}
+
+	truncated := truncateEvictions(summary, 1)
+	if len(truncated.EvictedPods) != 1 || truncated.Total != 3 || len(summary.EvictedPods) != 2 {
+		t.Errorf("expected a copy listing 1 of the 3 pods, got %+v", truncated)
+	}
+}
@@ -0,0 +1,146 @@
+package descheduler
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	deschedulerv1beta1 "github.com/skckadiyala/descheduler-operator/pkg/apis/descheduler/v1beta1"
+	batch "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ReasonImageFallback is the reason of the Degraded condition while the CronJob runs the default image
+const ReasonImageFallback = "ImageFallback"
+
+// ReasonImageFallbackRefused is the reason of the Degraded condition while spec.image fails to pull and the default
+// image doesn't support the spec
+const ReasonImageFallbackRefused = "ImageFallbackRefused"
+
+// imagePullFailureReasons are the waiting reasons of a container whose image can't be pulled
+var imagePullFailureReasons = []string{"ErrImagePull", "ImagePullBackOff", "InvalidImageName", "ErrImageNeverPull"}
+
+func isImagePullFailure(reason string) bool {
+	for _, imagePullFailureReason := range imagePullFailureReasons {
+		if reason == imagePullFailureReason {
+			return true
+		}
+	}
+	return false
+}
+
+// imagePullFailure returns why the descheduler container of the pod can't pull its image, or an empty string
+func imagePullFailure(pod *v1.Pod) string {
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.Name == DeschedulerContainerName && status.State.Waiting != nil && isImagePullFailure(status.State.Waiting.Reason) {
+			return status.State.Waiting.Reason
+		}
+	}
+	return ""
+}
+
+// deschedulerImage returns the image the cron job runs, the default image while spec.image is falling back
+func deschedulerImage(descheduler *deschedulerv1beta1.Descheduler) string {
+	if fallback := descheduler.Status.ImageFallback; fallback != nil && fallback.FailedImage == descheduler.Spec.Image {
+		return fallback.Image
+	}
+	return descheduler.Spec.Image
+}
+
+// effectiveSpec returns the spec with the image the workload runs, the policy version, the flags and the
+// compatibility check follow the default image while spec.image is falling back
+func effectiveSpec(descheduler *deschedulerv1beta1.Descheduler) deschedulerv1beta1.DeschedulerSpec {
+	spec := descheduler.Spec
+	spec.Image = deschedulerImage(descheduler)
+	return spec
+}
+
+// updateImageFallback switches the Descheduler to the default image once spec.image failed to pull in
+// ImagePullFailureThreshold consecutive runs, and back to spec.image once it changes. Deployment mode has no runs to
+// count, its pod keeps retrying to pull the image, so it never falls back. It returns why the Descheduler can't fall
+// back when the default image doesn't support the spec.
+func (r *ReconcileDescheduler) updateImageFallback(descheduler *deschedulerv1beta1.Descheduler) (string, error) {
+	if fallback := descheduler.Status.ImageFallback; fallback != nil {
+		if fallback.FailedImage == descheduler.Spec.Image {
+			return "", nil
+		}
+		log.Printf("Image of descheduler %s/%s changed from %s, stop falling back", descheduler.Namespace, descheduler.Name, fallback.FailedImage)
+		r.recorder.Eventf(descheduler, v1.EventTypeNormal, "ImageFallbackReverted",
+			"spec.image changed from %s to %s, stopped running %s", fallback.FailedImage, descheduler.Spec.Image, fallback.Image)
+		descheduler.Status.ImageFallback = nil
+		return "", nil
+	}
+
+	threshold := descheduler.Spec.ImagePullFailureThreshold
+	if threshold == nil || *threshold == 0 || descheduler.Spec.Image == DefaultImage ||
+		descheduler.Spec.Mode == deschedulerv1beta1.ModeDeployment {
+		return "", nil
+	}
+	failures, reason := countImagePullFailures(descheduler.Status.Runs, descheduler.Spec.Image)
+	if failures < *threshold {
+		return "", nil
+	}
+	fallbackSpec := descheduler.Spec
+	fallbackSpec.Image = DefaultImage
+	if unsupported, _ := UnsupportedSettings(fallbackSpec); len(unsupported) != 0 {
+		refused := fmt.Sprintf("image %s failed to pull in %d consecutive runs (%s), %s doesn't support the spec: %s",
+			descheduler.Spec.Image, failures, reason, DefaultImage, strings.Join(unsupported, ", "))
+		if condition := descheduler.Status.GetCondition(deschedulerv1beta1.ConditionDegraded); condition == nil ||
+			condition.Reason != ReasonImageFallbackRefused {
+			log.Printf("Not falling back descheduler %s/%s: %s", descheduler.Namespace, descheduler.Name, refused)
+			r.recorder.Event(descheduler, v1.EventTypeWarning, ReasonImageFallbackRefused, refused)
+		}
+		return refused, nil
+	}
+
+	log.Printf("Image %s of descheduler %s/%s failed to pull %d times, falling back to %s", descheduler.Spec.Image,
+		descheduler.Namespace, descheduler.Name, failures, DefaultImage)
+	r.recorder.Eventf(descheduler, v1.EventTypeWarning, ReasonImageFallback,
+		"image %s failed to pull in %d consecutive runs (%s), running %s until spec.image changes", descheduler.Spec.Image, failures, reason, DefaultImage)
+	descheduler.Status.ImageFallback = &deschedulerv1beta1.ImageFallback{
+		FailedImage: descheduler.Spec.Image,
+		Image:       DefaultImage,
+		Reason:      reason,
+		Since:       metav1.Now(),
+	}
+	return "", r.deleteImagePullFailedJobs(descheduler)
+}
+
+// countImagePullFailures counts the most recent consecutive runs of image that failed to pull it, runs of other
+// images are skipped. It returns the reason of the most recent failure.
+func countImagePullFailures(runs []deschedulerv1beta1.RunSummary, image string) (int32, string) {
+	failures, reason := int32(0), ""
+	for _, run := range runs {
+		if run.Image != image {
+			continue
+		}
+		if !isImagePullFailure(run.FailureReason) {
+			break
+		}
+		if failures == 0 {
+			reason = run.FailureReason
+		}
+		failures++
+	}
+	return failures, reason
+}
+
+// deleteImagePullFailedJobs deletes the active jobs of the Descheduler stuck pulling spec.image, they never finish otherwise
+func (r *ReconcileDescheduler) deleteImagePullFailedJobs(descheduler *deschedulerv1beta1.Descheduler) error {
+	for _, run := range descheduler.Status.Runs {
+		if run.Result != deschedulerv1beta1.RunActive || run.Image != descheduler.Spec.Image || !isImagePullFailure(run.FailureReason) {
+			continue
+		}
+		job := &batch.Job{ObjectMeta: metav1.ObjectMeta{Name: run.JobName, Namespace: descheduler.Namespace}}
+		log.Printf("Deleting job %s/%s stuck pulling its image", job.Namespace, job.Name)
+		err := r.client.Delete(context.TODO(), job, client.PropagationPolicy(metav1.DeletePropagationBackground))
+		if err != nil && !errors.IsNotFound(err) {
+			return fmt.Errorf("error deleting job %s %v", job.Name, err)
+		}
+	}
+	return nil
+}
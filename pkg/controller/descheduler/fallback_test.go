@@ -0,0 +1,93 @@
+package descheduler
+
+import (
+	"strings"
+	"testing"
+
+	deschedulerv1beta1 "github.com/skckadiyala/descheduler-operator/pkg/apis/descheduler/v1beta1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+)
+
+func TestCountImagePullFailures(t *testing.T) {
+	const image = "descheduler:broken"
+	run := func(image, failureReason string) deschedulerv1beta1.RunSummary {
+		return deschedulerv1beta1.RunSummary{Image: image, FailureReason: failureReason}
+	}
+
+	tests := []struct {
+		name     string
+		runs     []deschedulerv1beta1.RunSummary
+		failures int32
+		reason   string
+	}{
+		{"no run", nil, 0, ""},
+		{"consecutive failures", []deschedulerv1beta1.RunSummary{
+			run(image, "ImagePullBackOff"), run(image, "ErrImagePull"), run(image, "ErrImagePull"),
+		}, 3, "ImagePullBackOff"},
+		{"stops at a run that pulled the image", []deschedulerv1beta1.RunSummary{
+			run(image, "ErrImagePull"), run(image, ""), run(image, "ErrImagePull"),
+		}, 1, "ErrImagePull"},
+		{"other failures aren't pull failures", []deschedulerv1beta1.RunSummary{
+			run(image, "BackoffLimitExceeded"), run(image, "ErrImagePull"),
+		}, 0, ""},
+		{"runs of other images are skipped", []deschedulerv1beta1.RunSummary{
+			run(image, "InvalidImageName"), run(DefaultImage, ""), run(image, "InvalidImageName"),
+		}, 2, "InvalidImageName"},
+	}
+	for _, test := range tests {
+		failures, reason := countImagePullFailures(test.runs, image)
+		if failures != test.failures || reason != test.reason {
+			t.Errorf("%s: expected %d failures (%q), got %d (%q)", test.name, test.failures, test.reason, failures, reason)
+		}
+	}
+}
+
+func TestImageFallbackFollowsTheDefaultImage(t *testing.T) {
+	const image = "registry.k8s.io/descheduler/descheduler:v0.30.0"
+	pullFailed := func(descheduler *deschedulerv1beta1.Descheduler) {
+		descheduler.Spec.Image = image
+		descheduler.Spec.Flags = []deschedulerv1beta1.Param{{Name: deschedulerv1beta1.FlagNodeSelector, Value: "pool=workers"}}
+		for i := 0; i < 3; i++ {
+			descheduler.Status.Runs = append(descheduler.Status.Runs, deschedulerv1beta1.RunSummary{
+				Image: image, Result: deschedulerv1beta1.RunFailed, FailureReason: "ErrImagePull",
+			})
+		}
+	}
+
+	descheduler := testDescheduler()
+	pullFailed(descheduler)
+	r := &ReconcileDescheduler{scheme: runtime.NewScheme(), recorder: record.NewFakeRecorder(10)}
+	if refused, err := r.updateImageFallback(descheduler); err != nil || refused != "" {
+		t.Fatalf("updateImageFallback: refused %q, error %v", refused, err)
+	}
+	if descheduler.Status.ImageFallback == nil {
+		t.Fatal("expected the descheduler to fall back to the default image")
+	}
+	spec := effectiveSpec(descheduler)
+	if spec.Image != DefaultImage {
+		t.Errorf("expected the effective image %s, got %s", DefaultImage, spec.Image)
+	}
+	// v0.9.0 reads the v1alpha1 policy and the node-selector flag
+	if policy, err := generateConfigMapString(spec); err != nil || !strings.HasPrefix(policy, "apiVersion: descheduler/v1alpha1") {
+		t.Errorf("expected a v1alpha1 policy, got %v\n%s", err, policy)
+	}
+	if command, err := deschedulerCommand(descheduler); err != nil || !strings.Contains(strings.Join(command, " "), "--node-selector pool=workers") {
+		t.Errorf("expected the node-selector flag, got %v %v", err, command)
+	}
+	if unsupported, _ := UnsupportedSettings(spec); len(unsupported) != 0 {
+		t.Errorf("expected the spec supported by the default image, got %v", unsupported)
+	}
+
+	// The default image can't read a v1alpha2 policy
+	descheduler = testDescheduler()
+	pullFailed(descheduler)
+	descheduler.Spec.PolicyVersion = deschedulerv1beta1.PolicyVersionV1alpha2
+	refused, err := r.updateImageFallback(descheduler)
+	if err != nil || !strings.Contains(refused, "policyVersion v1alpha2 needs descheduler v0.29.0 or later") {
+		t.Errorf("expected the fallback refused for the policy version, got %q %v", refused, err)
+	}
+	if descheduler.Status.ImageFallback != nil || deschedulerImage(descheduler) != image {
+		t.Errorf("expected spec.image to keep running, got %s", deschedulerImage(descheduler))
+	}
+}
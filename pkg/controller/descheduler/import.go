@@ -0,0 +1,458 @@
+package descheduler
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	deschedulerv1beta1 "github.com/skckadiyala/descheduler-operator/pkg/apis/descheduler/v1beta1"
+	"gopkg.in/yaml.v2"
+	batchv1beta1 "k8s.io/api/batch/v1beta1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ImportSource is how an upstream descheduler runs its policy
+type ImportSource struct {
+	Schedule string
+	Suspend  bool
+	Image    string
+	// Args are the command and the arguments of the descheduler container
+	Args []string
+}
+
+// ImportCronJob returns how the CronJob of an upstream descheduler runs it, the first container is expected to be
+// descheduler
+func ImportCronJob(cronJob *batchv1beta1.CronJob) ImportSource {
+	source := ImportSource{Schedule: cronJob.Spec.Schedule}
+	if cronJob.Spec.Suspend != nil {
+		source.Suspend = *cronJob.Spec.Suspend
+	}
+	if containers := cronJob.Spec.JobTemplate.Spec.Template.Spec.Containers; len(containers) != 0 {
+		source.Image = containers[0].Image
+		source.Args = append(append([]string{}, containers[0].Command...), containers[0].Args...)
+	}
+	return source
+}
+
+// thresholdKeys are the resources of the policy thresholds
+var thresholdKeys = map[string]interface{}{"cpu": nil, "memory": nil, "pods": nil}
+
+// namespacesKeys are the keys of the namespaces filter of a strategy
+var namespacesKeys = map[string]interface{}{"include": nil, "exclude": nil}
+
+// strategyKeys returns the keys of a strategy of a policy.yaml with the params, every strategy accepts a priority
+// threshold and namespaced ones a namespaces filter
+func strategyKeys(namespaced bool, params map[string]interface{}) map[string]interface{} {
+	allParams := map[string]interface{}{"thresholdPriority": nil, "thresholdPriorityClassName": nil}
+	if namespaced {
+		allParams["namespaces"] = namespacesKeys
+	}
+	for key, value := range params {
+		allParams[key] = value
+	}
+	return map[string]interface{}{"enabled": nil, "params": allParams}
+}
+
+// importableKeys are the keys of a policy.yaml a Descheduler can express, nil marks a leaf
+var importableKeys = map[string]interface{}{
+	"apiVersion":                     nil,
+	"kind":                           nil,
+	"nodeSelector":                   nil,
+	"evictLocalStoragePods":          nil,
+	"evictSystemCriticalPods":        nil,
+	"ignorePvcPods":                  nil,
+	"maxNoOfPodsToEvictPerNode":      nil,
+	"maxNoOfPodsToEvictPerNamespace": nil,
+	"strategies": map[string]interface{}{
+		"LowNodeUtilization": strategyKeys(false, map[string]interface{}{
+			"nodeResourceUtilizationThresholds": map[string]interface{}{
+				"numberOfNodes":    nil,
+				"thresholds":       thresholdKeys,
+				"targetThresholds": thresholdKeys,
+			},
+		}),
+		"RemoveDuplicates":                        strategyKeys(true, nil),
+		"RemovePodsViolatingInterPodAntiAffinity": strategyKeys(true, nil),
+		"RemovePodsViolatingNodeAffinity": strategyKeys(true, map[string]interface{}{
+			"nodeAffinityType": nil,
+		}),
+		"RemovePodsViolatingNodeTaints": strategyKeys(true, map[string]interface{}{
+			"excludedTaints":          nil,
+			"includePreferNoSchedule": nil,
+		}),
+		"RemovePodsHavingTooManyRestarts": strategyKeys(true, map[string]interface{}{
+			"podsHavingTooManyRestarts": map[string]interface{}{
+				"podRestartThreshold":     nil,
+				"includingInitContainers": nil,
+			},
+		}),
+		"PodLifeTime": strategyKeys(true, map[string]interface{}{
+			"podLifeTime": map[string]interface{}{
+				"maxPodLifeTimeSeconds": nil,
+				"podStatusPhases":       nil,
+			},
+		}),
+		"RemovePodsViolatingTopologySpreadConstraint": strategyKeys(true, map[string]interface{}{
+			"includeSoftConstraints": nil,
+		}),
+		"HighNodeUtilization": strategyKeys(false, map[string]interface{}{
+			"nodeResourceUtilizationThresholds": map[string]interface{}{
+				"numberOfNodes": nil,
+				"thresholds":    thresholdKeys,
+			},
+		}),
+		"RemoveFailedPods": strategyKeys(true, map[string]interface{}{
+			"failedPods": map[string]interface{}{
+				"reasons":                 nil,
+				"includingInitContainers": nil,
+				"excludeOwnerKinds":       nil,
+				"minPodLifetimeSeconds":   nil,
+			},
+		}),
+	},
+}
+
+// ImportPolicy converts the policy.yaml of an upstream descheduler and how it runs into an equivalent Descheduler.
+// It returns the settings the Descheduler can't express, they are dropped.
+func ImportPolicy(name, namespace string, policyContent []byte, source ImportSource) (*deschedulerv1beta1.Descheduler, []string, error) {
+	raw := map[string]interface{}{}
+	if err := yaml.Unmarshal(policyContent, &raw); err != nil {
+		return nil, nil, fmt.Errorf("error unmarshalling descheduler policy %v", err)
+	}
+	if apiVersion, _ := raw["apiVersion"].(string); apiVersion != "descheduler/v1alpha1" {
+		return nil, nil, fmt.Errorf("unsupported policy apiVersion %q, expected descheduler/v1alpha1", apiVersion)
+	}
+	if kind, _ := raw["kind"].(string); kind != "DeschedulerPolicy" {
+		return nil, nil, fmt.Errorf("unsupported policy kind %q, expected DeschedulerPolicy", kind)
+	}
+	unsupported := unknownKeys(raw, importableKeys, "")
+	unsupported = append(unsupported, disabledStrategyParams(raw)...)
+	unsupported = append(unsupported, truncateThresholds(raw)...)
+	// The thresholds are parsed once truncated
+	policyContent, err := yaml.Marshal(raw)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error marshalling descheduler policy %v", err)
+	}
+	policy, err := ParsePolicy(policyContent)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	descheduler := &deschedulerv1beta1.Descheduler{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Descheduler",
+			APIVersion: deschedulerv1beta1.SchemeGroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: deschedulerv1beta1.DeschedulerSpec{
+			Schedule: source.Schedule,
+			Image:    source.Image,
+			Suspend:  source.Suspend,
+			EvictorOptions: deschedulerv1beta1.EvictorOptions{
+				EvictLocalStoragePods:          policy.EvictLocalStoragePods,
+				EvictSystemCriticalPods:        policy.EvictSystemCriticalPods,
+				IgnorePvcPods:                  policy.IgnorePvcPods,
+				MaxNoOfPodsToEvictPerNode:      policy.MaxNoOfPodsToEvictPerNode,
+				MaxNoOfPodsToEvictPerNamespace: policy.MaxNoOfPodsToEvictPerNamespace,
+			},
+		},
+	}
+	strategies := &descheduler.Spec.Strategies
+	if removeDuplicates := policy.Strategies.RemoveDuplicates; removeDuplicates.Enabled {
+		strategies.RemoveDuplicates = &deschedulerv1beta1.RemoveDuplicatesStrategy{
+			Namespaces:        importNamespaces(removeDuplicates.Params.Namespaces),
+			PriorityThreshold: importPriorityThreshold(removeDuplicates.Params.PolicyPriorityThreshold),
+		}
+	}
+	if interPodAntiAffinity := policy.Strategies.RemovePodsViolatingInterPodAntiAffinity; interPodAntiAffinity.Enabled {
+		strategies.RemovePodsViolatingInterPodAntiAffinity = &deschedulerv1beta1.RemovePodsViolatingInterPodAntiAffinityStrategy{
+			Namespaces:        importNamespaces(interPodAntiAffinity.Params.Namespaces),
+			PriorityThreshold: importPriorityThreshold(interPodAntiAffinity.Params.PolicyPriorityThreshold),
+		}
+	}
+	if lowNodeUtilization := policy.Strategies.LowNodeUtilization; lowNodeUtilization.Enabled {
+		thresholds := lowNodeUtilization.Params.NodeResourceUtilizationThresholds
+		strategies.LowNodeUtilization = &deschedulerv1beta1.LowNodeUtilizationStrategy{
+			NumberOfNodes: int32(thresholds.NumberOfNodes),
+			Thresholds: deschedulerv1beta1.ResourceThresholds{
+				CPU:    int32(thresholds.Thresholds.CPU),
+				Memory: int32(thresholds.Thresholds.Memory),
+				Pods:   int32(thresholds.Thresholds.Pods),
+			},
+			TargetThresholds: deschedulerv1beta1.ResourceThresholds{
+				CPU:    int32(thresholds.TargetThresholds.CPU),
+				Memory: int32(thresholds.TargetThresholds.Memory),
+				Pods:   int32(thresholds.TargetThresholds.Pods),
+			},
+			PriorityThreshold: importPriorityThreshold(lowNodeUtilization.Params.PolicyPriorityThreshold),
+		}
+	}
+	if nodeAffinity := policy.Strategies.RemovePodsViolatingNodeAffinity; nodeAffinity.Enabled {
+		strategies.RemovePodsViolatingNodeAffinity = &deschedulerv1beta1.RemovePodsViolatingNodeAffinityStrategy{
+			NodeAffinityType:  nodeAffinity.Params.NodeAffinityType,
+			Namespaces:        importNamespaces(nodeAffinity.Params.Namespaces),
+			PriorityThreshold: importPriorityThreshold(nodeAffinity.Params.PolicyPriorityThreshold),
+		}
+	}
+	if nodeTaints := policy.Strategies.RemovePodsViolatingNodeTaints; nodeTaints != nil && nodeTaints.Enabled {
+		strategies.RemovePodsViolatingNodeTaints = &deschedulerv1beta1.RemovePodsViolatingNodeTaintsStrategy{
+			ExcludedTaints:          nodeTaints.Params.ExcludedTaints,
+			IncludePreferNoSchedule: nodeTaints.Params.IncludePreferNoSchedule,
+			Namespaces:              importNamespaces(nodeTaints.Params.Namespaces),
+			PriorityThreshold:       importPriorityThreshold(nodeTaints.Params.PolicyPriorityThreshold),
+		}
+	}
+	if tooManyRestarts := policy.Strategies.RemovePodsHavingTooManyRestarts; tooManyRestarts != nil && tooManyRestarts.Enabled {
+		params := tooManyRestarts.Params.PodsHavingTooManyRestarts
+		strategies.RemovePodsHavingTooManyRestarts = &deschedulerv1beta1.RemovePodsHavingTooManyRestartsStrategy{
+			PodRestartThreshold:     int32(params.PodRestartThreshold),
+			IncludingInitContainers: params.IncludingInitContainers,
+			Namespaces:              importNamespaces(tooManyRestarts.Params.Namespaces),
+			PriorityThreshold:       importPriorityThreshold(tooManyRestarts.Params.PolicyPriorityThreshold),
+		}
+	}
+	if podLifeTime := policy.Strategies.PodLifeTime; podLifeTime != nil && podLifeTime.Enabled {
+		strategy := &deschedulerv1beta1.PodLifeTimeStrategy{
+			MaxPodLifeTimeSeconds: podLifeTime.Params.PodLifeTime.MaxPodLifeTimeSeconds,
+			Namespaces:            importNamespaces(podLifeTime.Params.Namespaces),
+			PriorityThreshold:     importPriorityThreshold(podLifeTime.Params.PolicyPriorityThreshold),
+		}
+		for _, phase := range podLifeTime.Params.PodLifeTime.PodStatusPhases {
+			strategy.PodStatusPhases = append(strategy.PodStatusPhases, v1.PodPhase(phase))
+		}
+		strategies.PodLifeTime = strategy
+	}
+	if topologySpread := policy.Strategies.RemovePodsViolatingTopologySpreadConstraint; topologySpread != nil && topologySpread.Enabled {
+		strategies.RemovePodsViolatingTopologySpreadConstraint = &deschedulerv1beta1.RemovePodsViolatingTopologySpreadConstraintStrategy{
+			IncludeSoftConstraints: topologySpread.Params.IncludeSoftConstraints,
+			Namespaces:             importNamespaces(topologySpread.Params.Namespaces),
+			PriorityThreshold:      importPriorityThreshold(topologySpread.Params.PolicyPriorityThreshold),
+		}
+	}
+	if highNodeUtilization := policy.Strategies.HighNodeUtilization; highNodeUtilization != nil && highNodeUtilization.Enabled {
+		thresholds := highNodeUtilization.Params.NodeResourceUtilizationThresholds
+		strategies.HighNodeUtilization = &deschedulerv1beta1.HighNodeUtilizationStrategy{
+			NumberOfNodes: int32(thresholds.NumberOfNodes),
+			Thresholds: deschedulerv1beta1.ResourceThresholds{
+				CPU:    int32(thresholds.Thresholds.CPU),
+				Memory: int32(thresholds.Thresholds.Memory),
+				Pods:   int32(thresholds.Thresholds.Pods),
+			},
+			PriorityThreshold: importPriorityThreshold(highNodeUtilization.Params.PolicyPriorityThreshold),
+		}
+	}
+	if failedPods := policy.Strategies.RemoveFailedPods; failedPods != nil && failedPods.Enabled {
+		params := failedPods.Params.FailedPods
+		strategies.RemoveFailedPods = &deschedulerv1beta1.RemoveFailedPodsStrategy{
+			Reasons:                 params.Reasons,
+			IncludingInitContainers: params.IncludingInitContainers,
+			ExcludeOwnerKinds:       params.ExcludeOwnerKinds,
+			MinPodLifetimeSeconds:   params.MinPodLifetimeSeconds,
+			Namespaces:              importNamespaces(failedPods.Params.Namespaces),
+			PriorityThreshold:       importPriorityThreshold(failedPods.Params.PolicyPriorityThreshold),
+		}
+	}
+	unsupported = append(unsupported, importArgs(descheduler, source.Args)...)
+	if len(policy.NodeSelector) != 0 {
+		// descheduler prefers the node selector of the policy to the flag
+		setFlag(descheduler, deschedulerv1beta1.FlagNodeSelector, policy.NodeSelector)
+	}
+
+	// Validate with the defaults the webhook applies, they are left out of the imported Descheduler
+	defaulted := descheduler.DeepCopy()
+	defaulted.Default()
+	if err := defaulted.Validate(); err != nil {
+		return nil, unsupported, err
+	}
+	return descheduler, unsupported, nil
+}
+
+// importNamespaces returns the namespaces filter of a strategy of the policy
+func importNamespaces(namespaces *PolicyNamespaces) *deschedulerv1beta1.Namespaces {
+	if namespaces == nil {
+		return nil
+	}
+	return &deschedulerv1beta1.Namespaces{Include: namespaces.Include, Exclude: namespaces.Exclude}
+}
+
+// importPriorityThreshold returns the priority threshold of a strategy of the policy
+func importPriorityThreshold(threshold PolicyPriorityThreshold) deschedulerv1beta1.PriorityThreshold {
+	return deschedulerv1beta1.PriorityThreshold{
+		ThresholdPriority:          threshold.ThresholdPriority,
+		ThresholdPriorityClassName: threshold.ThresholdPriorityClassName,
+	}
+}
+
+// disabledStrategyParams returns the strategies of raw disabled with params, they aren't imported
+func disabledStrategyParams(raw map[string]interface{}) []string {
+	var disabled []string
+	for name, strategy := range toStringMap(raw["strategies"]) {
+		entries := toStringMap(strategy)
+		if enabled, _ := entries["enabled"].(bool); !enabled && len(toStringMap(entries["params"])) != 0 {
+			disabled = append(disabled, fmt.Sprintf("params of the disabled strategies.%s", name))
+		}
+	}
+	sort.Strings(disabled)
+	return disabled
+}
+
+// thresholdPaths are the thresholds of the policy, descheduler reads percentages with decimals
+var thresholdPaths = [][]string{
+	{"strategies", "LowNodeUtilization", "params", "nodeResourceUtilizationThresholds", "thresholds"},
+	{"strategies", "LowNodeUtilization", "params", "nodeResourceUtilizationThresholds", "targetThresholds"},
+	{"strategies", "HighNodeUtilization", "params", "nodeResourceUtilizationThresholds", "thresholds"},
+}
+
+// truncateThresholds truncates the thresholds of raw to the whole percentages of the spec and returns the ones
+// losing their decimals
+func truncateThresholds(raw map[string]interface{}) []string {
+	var truncated []string
+	for _, path := range thresholdPaths {
+		var value interface{} = raw
+		for _, key := range path {
+			value = toStringMap(value)[key]
+		}
+		thresholds, ok := value.(map[interface{}]interface{})
+		if !ok {
+			continue
+		}
+		for resource, threshold := range thresholds {
+			percent, ok := threshold.(float64)
+			if !ok {
+				continue
+			}
+			thresholds[resource] = int(percent)
+			if percent != math.Trunc(percent) {
+				truncated = append(truncated, fmt.Sprintf("decimals of %s.%v (%v, imported as %d)",
+					strings.Join(path, "."), resource, percent, int(percent)))
+			}
+		}
+	}
+	sort.Strings(truncated)
+	return truncated
+}
+
+// unknownKeys returns the paths of the keys of raw missing from known
+func unknownKeys(raw interface{}, known map[string]interface{}, path string) []string {
+	var unknown []string
+	for key, value := range toStringMap(raw) {
+		keyPath := key
+		if path != "" {
+			keyPath = path + "." + key
+		}
+		child, ok := known[key]
+		if !ok {
+			unknown = append(unknown, keyPath)
+			continue
+		}
+		if childKeys, ok := child.(map[string]interface{}); ok {
+			unknown = append(unknown, unknownKeys(value, childKeys, keyPath)...)
+		}
+	}
+	sort.Strings(unknown)
+	return unknown
+}
+
+// toStringMap returns the entries of a YAML mapping, yaml.v2 decodes nested mappings with interface{} keys
+func toStringMap(raw interface{}) map[string]interface{} {
+	entries := map[string]interface{}{}
+	switch m := raw.(type) {
+	case map[string]interface{}:
+		return m
+	case map[interface{}]interface{}:
+		for key, value := range m {
+			entries[fmt.Sprint(key)] = value
+		}
+	}
+	return entries
+}
+
+// importArgs sets the log verbosity, dry run and flags of the Descheduler from the descheduler arguments and
+// returns the arguments it can't express
+func importArgs(descheduler *deschedulerv1beta1.Descheduler, args []string) []string {
+	var unsupported []string
+	if len(args) != 0 && !strings.HasPrefix(args[0], "-") {
+		// The descheduler binary
+		args = args[1:]
+	}
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if !strings.HasPrefix(arg, "-") {
+			unsupported = append(unsupported, fmt.Sprintf("argument %s", arg))
+			continue
+		}
+		name := strings.TrimLeft(arg, "-")
+		value, hasValue := "", false
+		if j := strings.Index(name, "="); j >= 0 {
+			name, value, hasValue = name[:j], name[j+1:], true
+		}
+		// nextValue consumes the value of a flag given as a separate argument
+		nextValue := func() string {
+			if !hasValue && i+1 < len(args) && !strings.HasPrefix(args[i+1], "-") {
+				i++
+				value, hasValue = args[i], true
+			}
+			return value
+		}
+
+		switch name {
+		case "policy-config-file":
+			// The operator mounts the policy it renders
+			nextValue()
+		case "v":
+			verbosity, err := strconv.ParseInt(nextValue(), 10, 32)
+			if err != nil {
+				unsupported = append(unsupported, fmt.Sprintf("flag --v=%s", value))
+				continue
+			}
+			logVerbosity := int32(verbosity)
+			descheduler.Spec.LogVerbosity = &logVerbosity
+		case deschedulerv1beta1.FlagDryRun:
+			// A boolean flag, only its --dry-run=false form has a value
+			descheduler.Spec.DryRun = !hasValue || value != "false"
+		case deschedulerv1beta1.FlagDeschedulingInterval:
+			// The CronJob runs descheduler once per schedule
+			unsupported = append(unsupported, fmt.Sprintf("flag --%s=%s", name, nextValue()))
+		case "evict-local-storage-pods":
+			// The flag of descheduler releases before the evictor options of the policy
+			descheduler.Spec.EvictLocalStoragePods = !hasValue || value != "false"
+		case "max-pods-to-evict-per-node":
+			maxPods, err := strconv.ParseInt(nextValue(), 10, 32)
+			if err != nil || maxPods < 0 {
+				unsupported = append(unsupported, fmt.Sprintf("flag --%s=%s", name, value))
+				continue
+			} else if maxPods == 0 {
+				// 0 doesn't limit the evictions
+				continue
+			}
+			maxPodsPerNode := int32(maxPods)
+			descheduler.Spec.MaxNoOfPodsToEvictPerNode = &maxPodsPerNode
+		case deschedulerv1beta1.FlagNodeSelector:
+			setFlag(descheduler, name, nextValue())
+		default:
+			if nextValue() != "" {
+				unsupported = append(unsupported, fmt.Sprintf("flag --%s=%s", name, value))
+			} else {
+				unsupported = append(unsupported, fmt.Sprintf("flag --%s", name))
+			}
+		}
+	}
+	return unsupported
+}
+
+// setFlag sets a flag of the Descheduler, replacing its previous value
+func setFlag(descheduler *deschedulerv1beta1.Descheduler, name, value string) {
+	for i := range descheduler.Spec.Flags {
+		if descheduler.Spec.Flags[i].Name == name {
+			descheduler.Spec.Flags[i].Value = value
+			return
+		}
+	}
+	descheduler.Spec.Flags = append(descheduler.Spec.Flags, deschedulerv1beta1.Param{Name: name, Value: value})
+}
@@ -0,0 +1,118 @@
+package descheduler
+
+import (
+	"reflect"
+	"testing"
+
+	deschedulerv1beta1 "github.com/skckadiyala/descheduler-operator/pkg/apis/descheduler/v1beta1"
+)
+
+func TestImportPolicy(t *testing.T) {
+	policy := `apiVersion: descheduler/v1alpha1
+kind: DeschedulerPolicy
+nodeSelector: pool=workers
+evictLocalStoragePods: true
+unknownSetting: true
+strategies:
+  LowNodeUtilization:
+    enabled: true
+    params:
+      nodeResourceUtilizationThresholds:
+        thresholds:
+          cpu: 20.5
+          memory: 20.0
+          pods: 20
+        targetThresholds:
+          cpu: 50
+          memory: 50.75
+          pods: 50
+  RemoveDuplicates:
+    enabled: false
+    params:
+      namespaces:
+        exclude: [kube-system]
+  RemovePodsViolatingInterPodAntiAffinity:
+    enabled: false
+  PodLifeTime:
+    enabled: true
+    params:
+      podLifeTime:
+        maxPodLifeTimeSeconds: 86400
+        unknownParam: 1
+`
+	source := ImportSource{
+		Schedule: "*/30 * * * *",
+		Image:    "k8s.gcr.io/descheduler/descheduler:v0.22.0",
+		Args:     []string{"/bin/descheduler", "--policy-config-file", "/policy-dir/policy.yaml", "--node-selector=pool=system", "--v", "4", "--leader-elect"},
+	}
+	descheduler, unsupported, err := ImportPolicy("descheduler", "kube-system", []byte(policy), source)
+	if err != nil {
+		t.Fatalf("ImportPolicy: %v", err)
+	}
+
+	expectedUnsupported := []string{
+		"strategies.PodLifeTime.params.podLifeTime.unknownParam",
+		"unknownSetting",
+		"params of the disabled strategies.RemoveDuplicates",
+		"decimals of strategies.LowNodeUtilization.params.nodeResourceUtilizationThresholds.targetThresholds.memory (50.75, imported as 50)",
+		"decimals of strategies.LowNodeUtilization.params.nodeResourceUtilizationThresholds.thresholds.cpu (20.5, imported as 20)",
+		"flag --leader-elect",
+	}
+	if !reflect.DeepEqual(unsupported, expectedUnsupported) {
+		t.Errorf("expected unsupported %q\ngot                  %q", expectedUnsupported, unsupported)
+	}
+
+	spec := descheduler.Spec
+	expectedFlags := []deschedulerv1beta1.Param{{Name: deschedulerv1beta1.FlagNodeSelector, Value: "pool=workers"}}
+	if !reflect.DeepEqual(spec.Flags, expectedFlags) {
+		t.Errorf("expected the node selector of the policy as flags %v, got %v", expectedFlags, spec.Flags)
+	}
+	lowNodeUtilization := spec.Strategies.LowNodeUtilization
+	if lowNodeUtilization == nil {
+		t.Fatal("LowNodeUtilization wasn't imported")
+	}
+	expectedThresholds := deschedulerv1beta1.ResourceThresholds{CPU: 20, Memory: 20, Pods: 20}
+	if lowNodeUtilization.Thresholds != expectedThresholds {
+		t.Errorf("expected thresholds %+v, got %+v", expectedThresholds, lowNodeUtilization.Thresholds)
+	}
+	expectedTargetThresholds := deschedulerv1beta1.ResourceThresholds{CPU: 50, Memory: 50, Pods: 50}
+	if lowNodeUtilization.TargetThresholds != expectedTargetThresholds {
+		t.Errorf("expected target thresholds %+v, got %+v", expectedTargetThresholds, lowNodeUtilization.TargetThresholds)
+	}
+	if spec.Strategies.RemoveDuplicates != nil || spec.Strategies.RemovePodsViolatingInterPodAntiAffinity != nil {
+		t.Error("disabled strategies were imported")
+	}
+	if podLifeTime := spec.Strategies.PodLifeTime; podLifeTime == nil || podLifeTime.MaxPodLifeTimeSeconds != 86400 {
+		t.Errorf("expected PodLifeTime with a max life time of 86400s, got %+v", podLifeTime)
+	}
+	if !spec.EvictLocalStoragePods || spec.Schedule != source.Schedule || spec.Image != source.Image {
+		t.Errorf("evictor options, schedule or image not imported: %+v", spec)
+	}
+	if spec.LogVerbosity == nil || *spec.LogVerbosity != 4 {
+		t.Errorf("expected log verbosity 4, got %v", spec.LogVerbosity)
+	}
+}
+
+func TestImportPolicyErrors(t *testing.T) {
+	tests := map[string]string{
+		"apiVersion": "apiVersion: descheduler/v1alpha2\nkind: DeschedulerPolicy\n",
+		"kind":       "apiVersion: descheduler/v1alpha1\nkind: Policy\n",
+		"invalid": `apiVersion: descheduler/v1alpha1
+kind: DeschedulerPolicy
+strategies:
+  LowNodeUtilization:
+    enabled: true
+    params:
+      nodeResourceUtilizationThresholds:
+        thresholds:
+          cpu: 80
+        targetThresholds:
+          cpu: 50
+`,
+	}
+	for name, policy := range tests {
+		if _, _, err := ImportPolicy("descheduler", "kube-system", []byte(policy), ImportSource{}); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
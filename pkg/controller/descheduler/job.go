@@ -0,0 +1,176 @@
+package descheduler
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+
+	deschedulerv1beta1 "github.com/skckadiyala/descheduler-operator/pkg/apis/descheduler/v1beta1"
+	appsv1 "k8s.io/api/apps/v1"
+	batch "k8s.io/api/batch/v1"
+	batchv1beta1 "k8s.io/api/batch/v1beta1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// generateDeschedulerRunJob generates the Job running descheduler once in Job mode. The job is named after the hash of
+// its spec, so a change of the policy, the flags or the image creates a new job and descheduler runs again with the new
+// spec, the outdated jobs are deleted. No job is created while the Descheduler is suspended.
+func (r *ReconcileDescheduler) generateDeschedulerRunJob(descheduler *deschedulerv1beta1.Descheduler) error {
+	if descheduler.Spec.Suspend {
+		return nil
+	}
+	desired, err := r.createRunJob(descheduler)
+	if err != nil {
+		log.Printf(" error while creating job %v", err)
+		return err
+	}
+	jobs, err := r.modeJobs(descheduler)
+	if err != nil {
+		return err
+	}
+	found := false
+	for i := range jobs {
+		if jobs[i].Name == desired.Name {
+			found = true
+			continue
+		}
+		if jobs[i].DeletionTimestamp != nil {
+			continue
+		}
+		log.Printf("Flags, image or policy mismatch in job %s/%s. Delete it", jobs[i].Namespace, jobs[i].Name)
+		err = r.client.Delete(context.TODO(), &jobs[i], client.PropagationPolicy(metav1.DeletePropagationBackground))
+		if err != nil && !errors.IsNotFound(err) {
+			log.Printf("Error while deleting job")
+			return err
+		}
+		r.recorder.Eventf(descheduler, v1.EventTypeNormal, "JobDeleted", "Deleted job %s, job %s runs with the descheduler spec", jobs[i].Name, desired.Name)
+		supersedeRun(descheduler, jobs[i].Name)
+	}
+	if found {
+		return nil
+	}
+
+	log.Printf("Creating a new job %s/%s\n", desired.Namespace, desired.Name)
+	err = r.client.Create(context.TODO(), desired)
+	if err != nil && !errors.IsAlreadyExists(err) {
+		log.Printf(" error while creating job %v", err)
+		return err
+	}
+	r.recordCreated(descheduler, "Job", desired.Name)
+	return nil
+}
+
+// modeJobs returns the jobs created by the Descheduler in Job mode, run-now jobs excluded
+func (r *ReconcileDescheduler) modeJobs(descheduler *deschedulerv1beta1.Descheduler) ([]batch.Job, error) {
+	jobs := &batch.JobList{}
+	err := r.client.List(context.TODO(), client.InNamespace(descheduler.Namespace).
+		MatchingLabels(map[string]string{deschedulerv1beta1.DeschedulerLabel: descheduler.Name}), jobs)
+	if err != nil {
+		return nil, err
+	}
+	modeJobs := make([]batch.Job, 0, len(jobs.Items))
+	for _, job := range jobs.Items {
+		if _, manual := job.Annotations[deschedulerv1beta1.RunNowAnnotation]; !manual && metav1.IsControlledBy(&job, descheduler) {
+			modeJobs = append(modeJobs, job)
+		}
+	}
+	return modeJobs, nil
+}
+
+// createRunJob creates the descheduler job of Job mode, owned by the Descheduler and named after the hash of its spec
+func (r *ReconcileDescheduler) createRunJob(descheduler *deschedulerv1beta1.Descheduler) (*batch.Job, error) {
+	template, err := deschedulerJobTemplate(descheduler)
+	if err != nil {
+		return nil, err
+	}
+	spec, err := json.Marshal(template)
+	if err != nil {
+		return nil, err
+	}
+	sum := sha256.Sum256(spec)
+	job := &batch.Job{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Job",
+			APIVersion: batch.SchemeGroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        fmt.Sprintf("%s-%s", descheduler.Name, hex.EncodeToString(sum[:])[:10]),
+			Namespace:   descheduler.Namespace,
+			Labels:      map[string]string{deschedulerv1beta1.DeschedulerLabel: descheduler.Name},
+			Annotations: template.Annotations,
+		},
+		Spec: template.Spec,
+	}
+	err = controllerutil.SetControllerReference(descheduler, job, r.scheme)
+	if err != nil {
+		return nil, fmt.Errorf("error setting owner references %v", err)
+	}
+	return job, nil
+}
+
+// deleteUnusedWorkloads deletes the CronJob, Deployment or Jobs the Descheduler created in another mode than its
+// current one. Objects not controlled by the Descheduler are left alone.
+func (r *ReconcileDescheduler) deleteUnusedWorkloads(descheduler *deschedulerv1beta1.Descheduler) error {
+	unused := []runtime.Object{}
+	if descheduler.Spec.Mode != deschedulerv1beta1.ModeCronJob {
+		unused = append(unused, &batchv1beta1.CronJob{})
+	}
+	if descheduler.Spec.Mode != deschedulerv1beta1.ModeDeployment {
+		unused = append(unused, &appsv1.Deployment{})
+	}
+	workloads := []runtime.Object{}
+	for _, workload := range unused {
+		err := r.client.Get(context.TODO(), types.NamespacedName{Name: descheduler.Name, Namespace: descheduler.Namespace}, workload)
+		if err != nil && errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return err
+		}
+		workloads = append(workloads, workload)
+	}
+	if descheduler.Spec.Mode != deschedulerv1beta1.ModeJob {
+		jobs, err := r.modeJobs(descheduler)
+		if err != nil {
+			return err
+		}
+		for i := range jobs {
+			workloads = append(workloads, &jobs[i])
+		}
+	}
+
+	for _, workload := range workloads {
+		object := workload.(metav1.Object)
+		if !metav1.IsControlledBy(object, descheduler) || object.GetDeletionTimestamp() != nil {
+			continue
+		}
+		kind := workloadKind(workload)
+		log.Printf("Descheduler %s/%s runs in %s mode, deleting %s %s", descheduler.Namespace, descheduler.Name, descheduler.Spec.Mode, kind, object.GetName())
+		err := r.client.Delete(context.TODO(), workload, client.PropagationPolicy(metav1.DeletePropagationBackground))
+		if err != nil && !errors.IsNotFound(err) {
+			return fmt.Errorf("error deleting %s %s %v", kind, object.GetName(), err)
+		}
+		r.recorder.Eventf(descheduler, v1.EventTypeNormal, "WorkloadDeleted", "Deleted %s %s, descheduler runs in %s mode", kind, object.GetName(), descheduler.Spec.Mode)
+	}
+	return nil
+}
+
+// workloadKind returns the kind of a workload, objects read through the client have no TypeMeta
+func workloadKind(workload runtime.Object) string {
+	switch workload.(type) {
+	case *batchv1beta1.CronJob:
+		return "CronJob"
+	case *appsv1.Deployment:
+		return "Deployment"
+	default:
+		return "Job"
+	}
+}
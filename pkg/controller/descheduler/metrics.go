@@ -0,0 +1,32 @@
+package descheduler
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	deschedulerv1beta1 "github.com/skckadiyala/descheduler-operator/pkg/apis/descheduler/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var (
+	evictedPodsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "descheduler_operator_evicted_pods_total",
+		Help: "Number of pods evicted by the descheduler jobs of a Descheduler",
+	}, []string{"namespace", "descheduler", "strategy", "pod_namespace"})
+	lastRunEvictedPods = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "descheduler_operator_last_run_evicted_pods",
+		Help: "Number of pods evicted by the last finished descheduler job of a Descheduler",
+	}, []string{"namespace", "descheduler"})
+)
+
+func init() {
+	// Served by the manager on the metrics port
+	metrics.Registry.MustRegister(evictedPodsTotal, lastRunEvictedPods)
+}
+
+// recordEvictionMetrics adds the pods evicted by a descheduler run to the metrics, it is called once per run
+// after its DeschedulerRun was completed with MetricsRecorded set. Nodes are not a label, they come and go with
+// the cluster autoscaler and are counted in the eviction summaries instead.
+func recordEvictionMetrics(descheduler *deschedulerv1beta1.Descheduler, evicted []deschedulerv1beta1.EvictedPod) {
+	for _, pod := range evicted {
+		evictedPodsTotal.WithLabelValues(descheduler.Namespace, descheduler.Name, pod.Strategy, pod.Namespace).Inc()
+	}
+}
@@ -0,0 +1,199 @@
+package descheduler
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+
+	deschedulerv1beta1 "github.com/skckadiyala/descheduler-operator/pkg/apis/descheduler/v1beta1"
+	"gopkg.in/yaml.v2"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	sigsyaml "sigs.k8s.io/yaml"
+)
+
+// Reasons of the PolicyValid condition when spec.policy refers to a missing or invalid policy
+const (
+	ReasonPolicyConfigMapNotFound = "PolicyConfigMapNotFound"
+	ReasonInvalidPolicy           = "InvalidPolicy"
+)
+
+// invalidPolicyError is returned when the policy of spec.policy is missing or doesn't parse
+type invalidPolicyError struct {
+	reason  string
+	message string
+}
+
+func (e *invalidPolicyError) Error() string {
+	return e.message
+}
+
+// policyPlugins are the v1alpha2 plugins the operator knows, the v1alpha1 strategies have the same names
+var policyPlugins = map[string]bool{
+	DefaultEvictorPlugin:                          true,
+	"RemoveDuplicates":                            true,
+	"LowNodeUtilization":                          true,
+	"RemovePodsViolatingInterPodAntiAffinity":     true,
+	"RemovePodsViolatingNodeAffinity":             true,
+	"RemovePodsViolatingNodeTaints":               true,
+	"RemovePodsHavingTooManyRestarts":             true,
+	"PodLifeTime":                                 true,
+	"RemovePodsViolatingTopologySpreadConstraint": true,
+	"HighNodeUtilization":                         true,
+	"RemoveFailedPods":                            true,
+}
+
+// ValidatePolicy checks the apiVersion and kind of a policy.yaml and the names of its strategies or plugins, and
+// returns its version. Their params and args are left to the descheduler, which reads fields the operator doesn't
+// render, such as labelSelector or useDeviationThresholds.
+func ValidatePolicy(policyContent []byte) (deschedulerv1beta1.PolicyVersion, error) {
+	header := struct {
+		APIVersion string `yaml:"apiVersion"`
+		Kind       string `yaml:"kind"`
+	}{}
+	if err := yaml.Unmarshal(policyContent, &header); err != nil {
+		return "", fmt.Errorf("error unmarshalling descheduler policy %v", err)
+	}
+	if header.Kind != "DeschedulerPolicy" {
+		return "", fmt.Errorf("unsupported policy kind %q, expected DeschedulerPolicy", header.Kind)
+	}
+	for version, renderer := range policyRenderers {
+		if renderer.APIVersion() != header.APIVersion {
+			continue
+		}
+		switch version {
+		case deschedulerv1beta1.PolicyVersionV1alpha2:
+			return version, validatePolicyV1alpha2(policyContent)
+		default:
+			return version, validatePolicyV1alpha1(policyContent)
+		}
+	}
+	return "", fmt.Errorf("unsupported policy apiVersion %q", header.APIVersion)
+}
+
+// validatePolicyV1alpha1 checks the strategies of a v1alpha1 policy
+func validatePolicyV1alpha1(policyContent []byte) error {
+	policy := struct {
+		Strategies map[string]interface{} `yaml:"strategies"`
+	}{}
+	if err := yaml.Unmarshal(policyContent, &policy); err != nil {
+		return fmt.Errorf("invalid descheduler policy %v", err)
+	}
+	var strategies []string
+	for strategy := range policy.Strategies {
+		strategies = append(strategies, strategy)
+	}
+	sort.Strings(strategies)
+	for _, strategy := range strategies {
+		if strategy == DefaultEvictorPlugin || !policyPlugins[strategy] {
+			return fmt.Errorf("unknown strategy %s", strategy)
+		}
+	}
+	return nil
+}
+
+// validatePolicyV1alpha2 checks the plugins the profiles of a v1alpha2 policy configure and enable
+func validatePolicyV1alpha2(policyContent []byte) error {
+	policy := &PolicyV1alpha2{}
+	if err := yaml.Unmarshal(policyContent, policy); err != nil {
+		return fmt.Errorf("invalid descheduler policy %v", err)
+	}
+	for _, profile := range policy.Profiles {
+		for _, config := range profile.PluginConfig {
+			if !policyPlugins[config.Name] {
+				return fmt.Errorf("profile %s configures unknown plugin %s", profile.Name, config.Name)
+			}
+		}
+		for _, plugins := range []*PolicyPluginSet{profile.Plugins.Filter, profile.Plugins.PreEvictionFilter,
+			profile.Plugins.Deschedule, profile.Plugins.Balance} {
+			if plugins == nil {
+				continue
+			}
+			for _, plugin := range plugins.Enabled {
+				if !policyPlugins[plugin] {
+					return fmt.Errorf("profile %s enables unknown plugin %s", profile.Name, plugin)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// resolvePolicy loads the policy of spec.policy and checks it. The policy of spec.policy.configMapRef is loaded in
+// spec.policy.raw in memory, so it is rendered, compared and rolled out like an embedded policy.
+func (r *ReconcileDescheduler) resolvePolicy(descheduler *deschedulerv1beta1.Descheduler) error {
+	policy := descheduler.Spec.Policy
+	if policy == nil {
+		return nil
+	}
+	if ref := policy.ConfigMapRef; ref != nil {
+		policyConfigMap := &v1.ConfigMap{}
+		err := r.client.Get(context.TODO(), types.NamespacedName{Name: ref.Name, Namespace: descheduler.Namespace}, policyConfigMap)
+		if err != nil && errors.IsNotFound(err) {
+			return &invalidPolicyError{reason: ReasonPolicyConfigMapNotFound,
+				message: fmt.Sprintf("ConfigMap %s of spec.policy.configMapRef not found", ref.Name)}
+		} else if err != nil {
+			log.Printf("Error while getting policy configmap %s %v", ref.Name, err)
+			return err
+		}
+		content, ok := policyConfigMap.Data[ref.Key]
+		if !ok {
+			return &invalidPolicyError{reason: ReasonInvalidPolicy,
+				message: fmt.Sprintf("ConfigMap %s of spec.policy.configMapRef has no key %s", ref.Name, ref.Key)}
+		}
+		raw, err := sigsyaml.YAMLToJSON([]byte(content))
+		if err != nil {
+			return &invalidPolicyError{reason: ReasonInvalidPolicy,
+				message: fmt.Sprintf("ConfigMap %s of spec.policy.configMapRef: %v", ref.Name, err)}
+		}
+		policy = policy.DeepCopy()
+		policy.Raw = &runtime.RawExtension{Raw: raw}
+		descheduler.Spec.Policy = policy
+	}
+
+	content, err := sigsyaml.JSONToYAML(policy.Raw.Raw)
+	if err == nil {
+		_, err = ValidatePolicy(content)
+	}
+	if err != nil {
+		return &invalidPolicyError{reason: ReasonInvalidPolicy, message: fmt.Sprintf("spec.policy: %v", err)}
+	}
+	return nil
+}
+
+// renderPolicySource returns the policy.yaml of spec.policy, the policy of configMapRef must have been resolved
+func renderPolicySource(policy *deschedulerv1beta1.PolicySource) (string, error) {
+	if policy.Raw == nil {
+		return "", fmt.Errorf("the policy of ConfigMap %s isn't loaded", policy.ConfigMapRef.Name)
+	}
+	policyContent, err := sigsyaml.JSONToYAML(policy.Raw.Raw)
+	if err != nil {
+		return "", fmt.Errorf("error marshalling descheduler policy %v", err)
+	}
+	return string(policyContent), nil
+}
+
+// policyConfigMapToDeschedulers returns the Deschedulers whose spec.policy.configMapRef refers to the ConfigMap,
+// so its changes are rolled into the ConfigMap the operator generates
+func policyConfigMapToDeschedulers(c client.Client, configMap metav1.Object) []reconcile.Request {
+	deschedulers := &deschedulerv1beta1.DeschedulerList{}
+	if err := c.List(context.TODO(), &client.ListOptions{Namespace: configMap.GetNamespace()}, deschedulers); err != nil {
+		log.Printf("Error while listing deschedulers %v", err)
+		return nil
+	}
+	var requests []reconcile.Request
+	for _, descheduler := range deschedulers.Items {
+		if policy := descheduler.Spec.Policy; policy != nil && policy.ConfigMapRef != nil && policy.ConfigMapRef.Name == configMap.GetName() {
+			requests = append(requests, reconcile.Request{
+				NamespacedName: types.NamespacedName{Name: descheduler.Name, Namespace: descheduler.Namespace},
+			})
+		}
+	}
+	return requests
+}
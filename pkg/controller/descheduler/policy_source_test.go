@@ -0,0 +1,86 @@
+package descheduler
+
+import (
+	"testing"
+
+	deschedulerv1beta1 "github.com/skckadiyala/descheduler-operator/pkg/apis/descheduler/v1beta1"
+)
+
+func TestValidatePolicy(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  string
+		version deschedulerv1beta1.PolicyVersion
+		valid   bool
+	}{
+		{"upstream v1alpha1 fields", `apiVersion: descheduler/v1alpha1
+kind: DeschedulerPolicy
+strategies:
+  LowNodeUtilization:
+    enabled: true
+    params:
+      nodeFit: true
+      useDeviationThresholds: true
+      nodeResourceUtilizationThresholds:
+        thresholds:
+          cpu: 20.5
+        targetThresholds:
+          cpu: 50.5
+  RemoveDuplicates:
+    enabled: true
+    params:
+      labelSelector:
+        matchLabels:
+          app: web
+`, deschedulerv1beta1.PolicyVersionV1alpha1, true},
+		{"unknown v1alpha1 strategy", `apiVersion: descheduler/v1alpha1
+kind: DeschedulerPolicy
+strategies:
+  RemoveEverything:
+    enabled: true
+`, "", false},
+		{"upstream v1alpha2 args", `apiVersion: descheduler/v1alpha2
+kind: DeschedulerPolicy
+profiles:
+- name: default
+  pluginConfig:
+  - name: DefaultEvictor
+    args:
+      nodeFit: true
+      labelSelector:
+        matchLabels:
+          app: web
+  - name: LowNodeUtilization
+    args:
+      useDeviationThresholds: true
+      thresholds:
+        cpu: 20.5
+      targetThresholds:
+        cpu: 50.5
+  plugins:
+    balance:
+      enabled: [LowNodeUtilization]
+`, deschedulerv1beta1.PolicyVersionV1alpha2, true},
+		{"unknown v1alpha2 plugin", `apiVersion: descheduler/v1alpha2
+kind: DeschedulerPolicy
+profiles:
+- name: default
+  plugins:
+    deschedule:
+      enabled: [RemoveEverything]
+`, "", false},
+		{"unsupported apiVersion", "apiVersion: descheduler/v1\nkind: DeschedulerPolicy\n", "", false},
+		{"unsupported kind", "apiVersion: descheduler/v1alpha1\nkind: Policy\n", "", false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			version, err := ValidatePolicy([]byte(test.policy))
+			if valid := err == nil; valid != test.valid {
+				t.Fatalf("expected valid %v, got error %v", test.valid, err)
+			}
+			if test.valid && version != test.version {
+				t.Errorf("expected version %s, got %s", test.version, version)
+			}
+		})
+	}
+}
@@ -0,0 +1,265 @@
+package descheduler
+
+import (
+	deschedulerv1beta1 "github.com/skckadiyala/descheduler-operator/pkg/apis/descheduler/v1beta1"
+)
+
+// DefaultEvictorPlugin is the plugin filtering the pods the strategy plugins of a profile may evict
+const DefaultEvictorPlugin = "DefaultEvictor"
+
+// PolicyV1alpha2 is the descheduler/v1alpha2 policy.yaml file
+type PolicyV1alpha2 struct {
+	APIVersion                     string          `yaml:"apiVersion"`
+	Kind                           string          `yaml:"kind"`
+	Profiles                       []PolicyProfile `yaml:"profiles"`
+	NodeSelector                   string          `yaml:"nodeSelector,omitempty"`
+	MaxNoOfPodsToEvictPerNode      *int32          `yaml:"maxNoOfPodsToEvictPerNode,omitempty"`
+	MaxNoOfPodsToEvictPerNamespace *int32          `yaml:"maxNoOfPodsToEvictPerNamespace,omitempty"`
+}
+
+// PolicyProfile is a profile of the v1alpha2 policy.yaml file
+type PolicyProfile struct {
+	Name         string               `yaml:"name"`
+	PluginConfig []PolicyPluginConfig `yaml:"pluginConfig"`
+	Plugins      struct {
+		Filter            *PolicyPluginSet `yaml:"filter,omitempty"`
+		PreEvictionFilter *PolicyPluginSet `yaml:"preEvictionFilter,omitempty"`
+		Deschedule        *PolicyPluginSet `yaml:"deschedule,omitempty"`
+		Balance           *PolicyPluginSet `yaml:"balance,omitempty"`
+	} `yaml:"plugins"`
+}
+
+// PolicyPluginConfig are the args of a plugin of a profile, one of the *Args types
+type PolicyPluginConfig struct {
+	Name string      `yaml:"name"`
+	Args interface{} `yaml:"args"`
+}
+
+// PolicyPluginSet are the plugins enabled at an extension point of a profile
+type PolicyPluginSet struct {
+	Enabled  []string `yaml:"enabled"`
+	Disabled []string `yaml:"disabled,omitempty"`
+}
+
+// PolicyResourceThresholds are the thresholds of the utilization plugins, in percent
+type PolicyResourceThresholds struct {
+	CPU    int32 `yaml:"cpu,omitempty"`
+	Memory int32 `yaml:"memory,omitempty"`
+	Pods   int32 `yaml:"pods,omitempty"`
+}
+
+// PolicyPriorityThresholdV1alpha2 is the priority from which the default evictor of a profile doesn't evict pods
+type PolicyPriorityThresholdV1alpha2 struct {
+	Name  string `yaml:"name,omitempty"`
+	Value *int32 `yaml:"value,omitempty"`
+}
+
+// DefaultEvictorArgs are the args of the DefaultEvictor plugin
+type DefaultEvictorArgs struct {
+	EvictLocalStoragePods   bool                             `yaml:"evictLocalStoragePods,omitempty"`
+	EvictSystemCriticalPods bool                             `yaml:"evictSystemCriticalPods,omitempty"`
+	IgnorePvcPods           bool                             `yaml:"ignorePvcPods,omitempty"`
+	EvictFailedBarePods     bool                             `yaml:"evictFailedBarePods,omitempty"`
+	NodeFit                 bool                             `yaml:"nodeFit,omitempty"`
+	PriorityThreshold       *PolicyPriorityThresholdV1alpha2 `yaml:"priorityThreshold,omitempty"`
+}
+
+// RemoveDuplicatesArgs are the args of the RemoveDuplicates plugin
+type RemoveDuplicatesArgs struct {
+	Namespaces *PolicyNamespaces `yaml:"namespaces,omitempty"`
+}
+
+// LowNodeUtilizationArgs are the args of the LowNodeUtilization plugin
+type LowNodeUtilizationArgs struct {
+	Thresholds       PolicyResourceThresholds `yaml:"thresholds"`
+	TargetThresholds PolicyResourceThresholds `yaml:"targetThresholds"`
+	NumberOfNodes    int32                    `yaml:"numberOfNodes,omitempty"`
+}
+
+// InterPodAntiAffinityArgs are the args of the RemovePodsViolatingInterPodAntiAffinity plugin
+type InterPodAntiAffinityArgs struct {
+	Namespaces *PolicyNamespaces `yaml:"namespaces,omitempty"`
+}
+
+// NodeAffinityArgs are the args of the RemovePodsViolatingNodeAffinity plugin
+type NodeAffinityArgs struct {
+	NodeAffinityType []string          `yaml:"nodeAffinityType"`
+	Namespaces       *PolicyNamespaces `yaml:"namespaces,omitempty"`
+}
+
+// NodeTaintsArgs are the args of the RemovePodsViolatingNodeTaints plugin
+type NodeTaintsArgs struct {
+	ExcludedTaints          []string          `yaml:"excludedTaints,omitempty"`
+	IncludePreferNoSchedule bool              `yaml:"includePreferNoSchedule,omitempty"`
+	Namespaces              *PolicyNamespaces `yaml:"namespaces,omitempty"`
+}
+
+// TooManyRestartsArgs are the args of the RemovePodsHavingTooManyRestarts plugin
+type TooManyRestartsArgs struct {
+	PodRestartThreshold     int32             `yaml:"podRestartThreshold"`
+	IncludingInitContainers bool              `yaml:"includingInitContainers,omitempty"`
+	Namespaces              *PolicyNamespaces `yaml:"namespaces,omitempty"`
+}
+
+// PodLifeTimeArgs are the args of the PodLifeTime plugin, states replaces the podStatusPhases of v1alpha1
+type PodLifeTimeArgs struct {
+	MaxPodLifeTimeSeconds int64             `yaml:"maxPodLifeTimeSeconds"`
+	States                []string          `yaml:"states,omitempty"`
+	Namespaces            *PolicyNamespaces `yaml:"namespaces,omitempty"`
+}
+
+// TopologySpreadArgs are the args of the RemovePodsViolatingTopologySpreadConstraint plugin
+type TopologySpreadArgs struct {
+	IncludeSoftConstraints bool              `yaml:"includeSoftConstraints,omitempty"`
+	Namespaces             *PolicyNamespaces `yaml:"namespaces,omitempty"`
+}
+
+// HighNodeUtilizationArgs are the args of the HighNodeUtilization plugin
+type HighNodeUtilizationArgs struct {
+	Thresholds    PolicyResourceThresholds `yaml:"thresholds"`
+	NumberOfNodes int32                    `yaml:"numberOfNodes,omitempty"`
+}
+
+// FailedPodsArgs are the args of the RemoveFailedPods plugin
+type FailedPodsArgs struct {
+	Reasons                 []string          `yaml:"reasons,omitempty"`
+	IncludingInitContainers bool              `yaml:"includingInitContainers,omitempty"`
+	ExcludeOwnerKinds       []string          `yaml:"excludeOwnerKinds,omitempty"`
+	MinPodLifetimeSeconds   int64             `yaml:"minPodLifetimeSeconds,omitempty"`
+	Namespaces              *PolicyNamespaces `yaml:"namespaces,omitempty"`
+}
+
+// GeneratePolicyV1alpha2 returns the descheduler/v1alpha2 policy of the spec, spec.strategies are rendered as the
+// profile named default when the spec has no profiles
+func GeneratePolicyV1alpha2(spec deschedulerv1beta1.DeschedulerSpec) PolicyV1alpha2 {
+	policy := PolicyV1alpha2{
+		APIVersion:                     "descheduler/v1alpha2",
+		Kind:                           "DeschedulerPolicy",
+		NodeSelector:                   nodeSelector(spec),
+		MaxNoOfPodsToEvictPerNode:      spec.MaxNoOfPodsToEvictPerNode,
+		MaxNoOfPodsToEvictPerNamespace: spec.MaxNoOfPodsToEvictPerNamespace,
+	}
+	profiles := spec.Profiles
+	if len(profiles) == 0 {
+		profiles = []deschedulerv1beta1.DeschedulerProfile{{Name: deschedulerv1beta1.DefaultProfileName, Strategies: spec.Strategies}}
+	}
+	for _, profile := range profiles {
+		policy.Profiles = append(policy.Profiles, policyProfile(profile, spec))
+	}
+	return policy
+}
+
+// policyProfile returns the profile of the policy, the evictor options and the priority threshold of the spec
+// configure its default evictor
+func policyProfile(profile deschedulerv1beta1.DeschedulerProfile, spec deschedulerv1beta1.DeschedulerSpec) PolicyProfile {
+	policyProfile := PolicyProfile{Name: profile.Name}
+	threshold := profile.PriorityThreshold
+	if !threshold.IsSet() {
+		threshold = spec.PriorityThreshold
+	}
+	evictorArgs := DefaultEvictorArgs{
+		EvictLocalStoragePods:   spec.EvictLocalStoragePods,
+		EvictSystemCriticalPods: spec.EvictSystemCriticalPods,
+		IgnorePvcPods:           spec.IgnorePvcPods,
+	}
+	if threshold.IsSet() {
+		evictorArgs.PriorityThreshold = &PolicyPriorityThresholdV1alpha2{
+			Name:  threshold.ThresholdPriorityClassName,
+			Value: threshold.ThresholdPriority,
+		}
+	}
+	policyProfile.PluginConfig = append(policyProfile.PluginConfig, PolicyPluginConfig{Name: DefaultEvictorPlugin, Args: evictorArgs})
+	policyProfile.Plugins.Filter = &PolicyPluginSet{Enabled: []string{DefaultEvictorPlugin}}
+	policyProfile.Plugins.PreEvictionFilter = &PolicyPluginSet{Enabled: []string{DefaultEvictorPlugin}}
+
+	strategies := profile.Strategies
+	if removeDuplicates := strategies.RemoveDuplicates; removeDuplicates != nil {
+		policyProfile.balance("RemoveDuplicates", RemoveDuplicatesArgs{Namespaces: policyNamespaces(removeDuplicates.Namespaces)})
+	}
+	if lowNodeUtilization := strategies.LowNodeUtilization; lowNodeUtilization != nil {
+		policyProfile.balance("LowNodeUtilization", LowNodeUtilizationArgs{
+			Thresholds:       policyResourceThresholds(lowNodeUtilization.Thresholds),
+			TargetThresholds: policyResourceThresholds(lowNodeUtilization.TargetThresholds),
+			NumberOfNodes:    lowNodeUtilization.NumberOfNodes,
+		})
+	}
+	if interPodAntiAffinity := strategies.RemovePodsViolatingInterPodAntiAffinity; interPodAntiAffinity != nil {
+		policyProfile.deschedule("RemovePodsViolatingInterPodAntiAffinity", InterPodAntiAffinityArgs{
+			Namespaces: policyNamespaces(interPodAntiAffinity.Namespaces),
+		})
+	}
+	if nodeAffinity := strategies.RemovePodsViolatingNodeAffinity; nodeAffinity != nil {
+		policyProfile.deschedule("RemovePodsViolatingNodeAffinity", NodeAffinityArgs{
+			NodeAffinityType: nodeAffinity.NodeAffinityType,
+			Namespaces:       policyNamespaces(nodeAffinity.Namespaces),
+		})
+	}
+	if nodeTaints := strategies.RemovePodsViolatingNodeTaints; nodeTaints != nil {
+		policyProfile.deschedule("RemovePodsViolatingNodeTaints", NodeTaintsArgs{
+			ExcludedTaints:          nodeTaints.ExcludedTaints,
+			IncludePreferNoSchedule: nodeTaints.IncludePreferNoSchedule,
+			Namespaces:              policyNamespaces(nodeTaints.Namespaces),
+		})
+	}
+	if tooManyRestarts := strategies.RemovePodsHavingTooManyRestarts; tooManyRestarts != nil {
+		policyProfile.deschedule("RemovePodsHavingTooManyRestarts", TooManyRestartsArgs{
+			PodRestartThreshold:     tooManyRestarts.PodRestartThreshold,
+			IncludingInitContainers: tooManyRestarts.IncludingInitContainers,
+			Namespaces:              policyNamespaces(tooManyRestarts.Namespaces),
+		})
+	}
+	if podLifeTime := strategies.PodLifeTime; podLifeTime != nil {
+		args := PodLifeTimeArgs{
+			MaxPodLifeTimeSeconds: podLifeTime.MaxPodLifeTimeSeconds,
+			Namespaces:            policyNamespaces(podLifeTime.Namespaces),
+		}
+		for _, phase := range podLifeTime.PodStatusPhases {
+			args.States = append(args.States, string(phase))
+		}
+		policyProfile.deschedule("PodLifeTime", args)
+	}
+	if topologySpread := strategies.RemovePodsViolatingTopologySpreadConstraint; topologySpread != nil {
+		policyProfile.balance("RemovePodsViolatingTopologySpreadConstraint", TopologySpreadArgs{
+			IncludeSoftConstraints: topologySpread.IncludeSoftConstraints,
+			Namespaces:             policyNamespaces(topologySpread.Namespaces),
+		})
+	}
+	if highNodeUtilization := strategies.HighNodeUtilization; highNodeUtilization != nil {
+		policyProfile.balance("HighNodeUtilization", HighNodeUtilizationArgs{
+			Thresholds:    policyResourceThresholds(highNodeUtilization.Thresholds),
+			NumberOfNodes: highNodeUtilization.NumberOfNodes,
+		})
+	}
+	if failedPods := strategies.RemoveFailedPods; failedPods != nil {
+		policyProfile.deschedule("RemoveFailedPods", FailedPodsArgs{
+			Reasons:                 failedPods.Reasons,
+			IncludingInitContainers: failedPods.IncludingInitContainers,
+			ExcludeOwnerKinds:       failedPods.ExcludeOwnerKinds,
+			MinPodLifetimeSeconds:   failedPods.MinPodLifetimeSeconds,
+			Namespaces:              policyNamespaces(failedPods.Namespaces),
+		})
+	}
+	return policyProfile
+}
+
+// balance enables the plugin at the balance extension point of the profile, with its args
+func (p *PolicyProfile) balance(plugin string, args interface{}) {
+	p.PluginConfig = append(p.PluginConfig, PolicyPluginConfig{Name: plugin, Args: args})
+	if p.Plugins.Balance == nil {
+		p.Plugins.Balance = &PolicyPluginSet{}
+	}
+	p.Plugins.Balance.Enabled = append(p.Plugins.Balance.Enabled, plugin)
+}
+
+// deschedule enables the plugin at the deschedule extension point of the profile, with its args
+func (p *PolicyProfile) deschedule(plugin string, args interface{}) {
+	p.PluginConfig = append(p.PluginConfig, PolicyPluginConfig{Name: plugin, Args: args})
+	if p.Plugins.Deschedule == nil {
+		p.Plugins.Deschedule = &PolicyPluginSet{}
+	}
+	p.Plugins.Deschedule.Enabled = append(p.Plugins.Deschedule.Enabled, plugin)
+}
+
+func policyResourceThresholds(thresholds deschedulerv1beta1.ResourceThresholds) PolicyResourceThresholds {
+	return PolicyResourceThresholds{CPU: thresholds.CPU, Memory: thresholds.Memory, Pods: thresholds.Pods}
+}
@@ -0,0 +1,110 @@
+package descheduler
+
+import (
+	"flag"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	deschedulerv1beta1 "github.com/skckadiyala/descheduler-operator/pkg/apis/descheduler/v1beta1"
+	v1 "k8s.io/api/core/v1"
+)
+
+var updateGolden = flag.Bool("update", false, "update the golden files of testdata")
+
+func TestGeneratePolicyV1alpha2(t *testing.T) {
+	priority := int32(1000)
+	maxPerNode := int32(5)
+	tests := []struct {
+		golden string
+		edit   func(spec *deschedulerv1beta1.DeschedulerSpec)
+	}{
+		{
+			// spec.strategies run as the default profile, split between the balance and deschedule extension points
+			golden: "policy-v1alpha2-default.yaml",
+			edit: func(spec *deschedulerv1beta1.DeschedulerSpec) {
+				spec.EvictLocalStoragePods = true
+				spec.IgnorePvcPods = true
+				spec.MaxNoOfPodsToEvictPerNode = &maxPerNode
+				spec.ThresholdPriorityClassName = "system-cluster-critical"
+				spec.Strategies.RemoveDuplicates.Namespaces = &deschedulerv1beta1.Namespaces{Exclude: []string{"kube-system"}}
+				spec.Strategies.LowNodeUtilization = &deschedulerv1beta1.LowNodeUtilizationStrategy{
+					Thresholds:       deschedulerv1beta1.ResourceThresholds{CPU: 20, Memory: 20, Pods: 20},
+					TargetThresholds: deschedulerv1beta1.ResourceThresholds{CPU: 50, Memory: 50, Pods: 50},
+					NumberOfNodes:    2,
+				}
+				spec.Strategies.RemovePodsViolatingNodeAffinity = &deschedulerv1beta1.RemovePodsViolatingNodeAffinityStrategy{
+					NodeAffinityType: []string{"requiredDuringSchedulingIgnoredDuringExecution"},
+				}
+				spec.Strategies.PodLifeTime = &deschedulerv1beta1.PodLifeTimeStrategy{
+					MaxPodLifeTimeSeconds: 86400,
+					PodStatusPhases:       []v1.PodPhase{v1.PodPending, v1.PodRunning},
+				}
+				spec.Strategies.RemovePodsViolatingTopologySpreadConstraint = &deschedulerv1beta1.RemovePodsViolatingTopologySpreadConstraintStrategy{
+					IncludeSoftConstraints: true,
+				}
+			},
+		},
+		{
+			// Named profiles, the threshold of the spec applies to the profiles not setting their own
+			golden: "policy-v1alpha2-profiles.yaml",
+			edit: func(spec *deschedulerv1beta1.DeschedulerSpec) {
+				spec.Strategies = deschedulerv1beta1.DeschedulerStrategies{}
+				spec.EvictSystemCriticalPods = true
+				spec.ThresholdPriority = &priority
+				spec.Profiles = []deschedulerv1beta1.DeschedulerProfile{
+					{
+						Name: "balance",
+						Strategies: deschedulerv1beta1.DeschedulerStrategies{
+							HighNodeUtilization: &deschedulerv1beta1.HighNodeUtilizationStrategy{
+								Thresholds: deschedulerv1beta1.ResourceThresholds{CPU: 20, Memory: 20},
+							},
+						},
+					},
+					{
+						Name: "cleanup",
+						Strategies: deschedulerv1beta1.DeschedulerStrategies{
+							RemoveFailedPods: &deschedulerv1beta1.RemoveFailedPodsStrategy{
+								Reasons:               []string{"NodeAffinity"},
+								ExcludeOwnerKinds:     []string{"Job"},
+								MinPodLifetimeSeconds: 3600,
+							},
+							RemovePodsHavingTooManyRestarts: &deschedulerv1beta1.RemovePodsHavingTooManyRestartsStrategy{
+								PodRestartThreshold:     100,
+								IncludingInitContainers: true,
+							},
+							RemovePodsViolatingNodeTaints: &deschedulerv1beta1.RemovePodsViolatingNodeTaintsStrategy{
+								ExcludedTaints: []string{"dedicated=gpu"},
+							},
+						},
+						PriorityThreshold: deschedulerv1beta1.PriorityThreshold{ThresholdPriorityClassName: "system-node-critical"},
+					},
+				}
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.golden, func(t *testing.T) {
+			descheduler := testDescheduler()
+			descheduler.Spec.PolicyVersion = deschedulerv1beta1.PolicyVersionV1alpha2
+			test.edit(&descheduler.Spec)
+			policy, err := v1alpha2Renderer{}.Render(descheduler.Spec)
+			if err != nil {
+				t.Fatalf("Render: %v", err)
+			}
+			golden := filepath.Join("testdata", test.golden)
+			if *updateGolden {
+				if err := ioutil.WriteFile(golden, []byte(policy), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			expected, err := ioutil.ReadFile(golden)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if policy != string(expected) {
+				t.Errorf("policy differs from %s, run go test -update to update it\n%s", golden, policy)
+			}
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package descheduler
+
+import (
+	"context"
+	"log"
+
+	deschedulerv1beta1 "github.com/skckadiyala/descheduler-operator/pkg/apis/descheduler/v1beta1"
+	schedulingv1beta1 "k8s.io/api/scheduling/v1beta1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// ReasonPriorityClassNotFound is the reason of the PolicyValid condition when a thresholdPriorityClassName refers to
+// a missing PriorityClass
+const ReasonPriorityClassNotFound = "PriorityClassNotFound"
+
+// missingPriorityClasses returns the PriorityClasses the priority thresholds of the Descheduler refer to and which
+// don't exist, descheduler would fail to resolve them
+func (r *ReconcileDescheduler) missingPriorityClasses(descheduler *deschedulerv1beta1.Descheduler) ([]string, error) {
+	var missing []string
+	for _, name := range descheduler.PriorityClassNames() {
+		priorityClass := &schedulingv1beta1.PriorityClass{}
+		err := r.client.Get(context.TODO(), types.NamespacedName{Name: name}, priorityClass)
+		if err != nil && errors.IsNotFound(err) {
+			missing = append(missing, name)
+		} else if err != nil {
+			log.Printf("Error while getting priority class %s %v", name, err)
+			return nil, err
+		}
+	}
+	return missing, nil
+}
+
+// priorityClassToDeschedulers returns the Deschedulers whose priority thresholds refer to the PriorityClass, so they
+// are validated again when it is created or deleted
+func priorityClassToDeschedulers(c client.Client, priorityClass metav1.Object) []reconcile.Request {
+	deschedulers := &deschedulerv1beta1.DeschedulerList{}
+	if err := c.List(context.TODO(), &client.ListOptions{}, deschedulers); err != nil {
+		log.Printf("Error while listing deschedulers %v", err)
+		return nil
+	}
+	var requests []reconcile.Request
+	for i := range deschedulers.Items {
+		descheduler := &deschedulers.Items[i]
+		for _, name := range descheduler.PriorityClassNames() {
+			if name == priorityClass.GetName() {
+				requests = append(requests, reconcile.Request{
+					NamespacedName: types.NamespacedName{Name: descheduler.Name, Namespace: descheduler.Namespace},
+				})
+				break
+			}
+		}
+	}
+	return requests
+}
@@ -0,0 +1,222 @@
+package descheduler
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"reflect"
+	"sort"
+	"time"
+
+	deschedulerv1beta1 "github.com/skckadiyala/descheduler-operator/pkg/apis/descheduler/v1beta1"
+	batch "k8s.io/api/batch/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// updateRunRecords creates a DeschedulerRun for every job of the Descheduler and completes it once the job finished.
+// It returns the evictions of the jobs completed during this reconcile, by job name.
+func (r *ReconcileDescheduler) updateRunRecords(descheduler *deschedulerv1beta1.Descheduler, jobs []batch.Job) (map[string]*deschedulerv1beta1.EvictionSummary, error) {
+	evictions := map[string]*deschedulerv1beta1.EvictionSummary{}
+	for i := range jobs {
+		job := &jobs[i]
+		record := &deschedulerv1beta1.DeschedulerRun{}
+		err := r.client.Get(context.TODO(), types.NamespacedName{Name: job.Name, Namespace: job.Namespace}, record)
+		if err != nil && errors.IsNotFound(err) {
+			record, err = r.createRunRecord(descheduler, job)
+			if err != nil {
+				return evictions, err
+			}
+		} else if err != nil {
+			return evictions, err
+		}
+		if record.Status.CompletionTime != nil {
+			// Completed records are not updated anymore
+			continue
+		}
+
+		status := record.Status.DeepCopy()
+		run := runFromJob(job)
+		for _, summary := range descheduler.Status.Runs {
+			if summary.JobName == job.Name {
+				run.PodName = summary.PodName
+			}
+		}
+		record.Status.PodName = run.PodName
+		record.Status.StartTime = run.StartTime
+		record.Status.CompletionTime = run.CompletionTime
+		record.Status.Result = run.Result
+		var evicted []deschedulerv1beta1.EvictedPod
+		if run.Result != deschedulerv1beta1.RunActive {
+			if evicted, err = r.readEvictions(job.Namespace, run.PodName); err != nil {
+				// The run is recorded with the error rather than never completed
+				log.Printf("%v", err)
+				record.Status.Evictions = &deschedulerv1beta1.EvictionSummary{JobName: job.Name, Error: err.Error()}
+			} else {
+				record.Status.Evictions = summarizeEvictions(job.Name, evicted, MaxRunEvictedPods)
+				record.Status.MetricsRecorded = !record.Spec.DryRun
+			}
+			evictions[job.Name] = record.Status.Evictions
+		}
+		if reflect.DeepEqual(status, &record.Status) {
+			continue
+		}
+		if err := r.client.Status().Update(context.TODO(), record); err != nil {
+			return evictions, fmt.Errorf("error updating descheduler run %s %v", record.Name, err)
+		}
+		// Counted once the completed record is stored, a failed update reads the logs again on the next reconcile
+		if record.Status.MetricsRecorded && !status.MetricsRecorded {
+			recordEvictionMetrics(descheduler, evicted)
+		}
+	}
+
+	records, err := r.listRunRecords(descheduler)
+	if err != nil {
+		return evictions, err
+	}
+	return evictions, r.completeDeletedRuns(descheduler, records, jobs)
+}
+
+// listRunRecords lists the DeschedulerRuns of the Descheduler. Records are not owned by the Descheduler so they are
+// kept as an audit trail once it is deleted, they are linked to it by label and spec.deschedulerName.
+func (r *ReconcileDescheduler) listRunRecords(descheduler *deschedulerv1beta1.Descheduler) ([]deschedulerv1beta1.DeschedulerRun, error) {
+	records := &deschedulerv1beta1.DeschedulerRunList{}
+	listOptions := client.InNamespace(descheduler.Namespace).MatchingLabels(map[string]string{
+		deschedulerv1beta1.DeschedulerLabel: descheduler.Name,
+	})
+	if err := r.client.List(context.TODO(), listOptions, records); err != nil {
+		return nil, fmt.Errorf("error listing descheduler runs %v", err)
+	}
+	linked := make([]deschedulerv1beta1.DeschedulerRun, 0, len(records.Items))
+	for _, record := range records.Items {
+		if record.Spec.DeschedulerName == descheduler.Name {
+			linked = append(linked, record)
+		}
+	}
+	return linked, nil
+}
+
+// completeDeletedRuns completes the DeschedulerRuns still active whose job was deleted, with the result of the run
+// history when it tells the job was superseded, failed otherwise
+func (r *ReconcileDescheduler) completeDeletedRuns(descheduler *deschedulerv1beta1.Descheduler,
+	records []deschedulerv1beta1.DeschedulerRun, jobs []batch.Job) error {
+	jobNames := map[string]bool{}
+	for _, job := range jobs {
+		jobNames[job.Name] = true
+	}
+	for i := range records {
+		record := &records[i]
+		if record.Status.CompletionTime != nil || jobNames[record.Spec.JobName] {
+			continue
+		}
+		now := metav1.Now()
+		record.Status.Result = deschedulerv1beta1.RunFailed
+		record.Status.CompletionTime = &now
+		for _, run := range descheduler.Status.Runs {
+			if run.JobName == record.Spec.JobName && run.Result == deschedulerv1beta1.RunSuperseded {
+				record.Status.Result = run.Result
+				record.Status.CompletionTime = run.CompletionTime.DeepCopy()
+			}
+		}
+		if err := r.client.Status().Update(context.TODO(), record); err != nil {
+			return fmt.Errorf("error updating descheduler run %s %v", record.Name, err)
+		}
+	}
+	return nil
+}
+
+// createRunRecord creates the DeschedulerRun of a job, with the policy, image and flags the job runs with
+func (r *ReconcileDescheduler) createRunRecord(descheduler *deschedulerv1beta1.Descheduler, job *batch.Job) (*deschedulerv1beta1.DeschedulerRun, error) {
+	record := &deschedulerv1beta1.DeschedulerRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      job.Name,
+			Namespace: job.Namespace,
+			Labels: map[string]string{
+				deschedulerv1beta1.DeschedulerLabel: descheduler.Name,
+			},
+		},
+		Spec: deschedulerv1beta1.DeschedulerRunSpec{
+			DeschedulerName: descheduler.Name,
+			JobName:         job.Name,
+			PolicyHash:      job.Annotations[PolicyHashAnnotation],
+			DryRun:          isDryRunJob(job),
+		},
+	}
+	if container := findContainer(job.Spec.Template.Spec.Containers, DeschedulerContainerName); container != nil {
+		record.Spec.Image = container.Image
+		if len(container.Command) > 1 {
+			record.Spec.Flags = container.Command[1:]
+		}
+	}
+	log.Printf("Creating descheduler run %s/%s", record.Namespace, record.Name)
+	if err := r.client.Create(context.TODO(), record); err != nil {
+		return nil, fmt.Errorf("error creating descheduler run %s %v", record.Name, err)
+	}
+	return record, nil
+}
+
+// enforceRunRetention deletes the completed DeschedulerRuns of the Descheduler beyond its retention count or older
+// than its retention age once their job is gone. It returns how long until the oldest record left expires, 0 when
+// none does.
+func (r *ReconcileDescheduler) enforceRunRetention(descheduler *deschedulerv1beta1.Descheduler) (time.Duration, error) {
+	retention := descheduler.Spec.RunRetention
+	if retention == nil || (retention.MaxCount == nil && retention.MaxAge == nil) {
+		return 0, nil
+	}
+	records, err := r.listRunRecords(descheduler)
+	if err != nil {
+		return 0, err
+	}
+	// A record deleted while its job exists would be created again, and the evictions of the job counted twice
+	jobs := &batch.JobList{}
+	if err := r.client.List(context.TODO(), client.InNamespace(descheduler.Namespace), jobs); err != nil {
+		return 0, fmt.Errorf("error listing jobs %v", err)
+	}
+	existingJobs := make(map[string]bool, len(jobs.Items))
+	for _, job := range jobs.Items {
+		existingJobs[job.Name] = true
+	}
+	expired, nextExpiry := expiredRunRecords(records, retention, existingJobs, time.Now())
+	for i := range expired {
+		log.Printf("Deleting descheduler run %s/%s beyond retention", expired[i].Namespace, expired[i].Name)
+		if err := r.client.Delete(context.TODO(), &expired[i]); err != nil && !errors.IsNotFound(err) {
+			return 0, fmt.Errorf("error deleting descheduler run %s %v", expired[i].Name, err)
+		}
+	}
+	return nextExpiry, nil
+}
+
+// expiredRunRecords returns the completed records beyond the retention count or older than the retention age, and
+// how long until the oldest record kept gets older than the retention age. The records of existing jobs are kept,
+// the deletion of the job reconciles the Descheduler again.
+func expiredRunRecords(records []deschedulerv1beta1.DeschedulerRun, retention *deschedulerv1beta1.RunRetention,
+	existingJobs map[string]bool, now time.Time) ([]deschedulerv1beta1.DeschedulerRun, time.Duration) {
+	completed := make([]deschedulerv1beta1.DeschedulerRun, 0, len(records))
+	for _, record := range records {
+		if record.Status.CompletionTime != nil {
+			completed = append(completed, record)
+		}
+	}
+	// Most recent first
+	sort.Slice(completed, func(i, j int) bool {
+		return completed[j].Status.CompletionTime.Before(completed[i].Status.CompletionTime)
+	})
+	var expired []deschedulerv1beta1.DeschedulerRun
+	var nextExpiry time.Duration
+	for i := range completed {
+		age := now.Sub(completed[i].Status.CompletionTime.Time)
+		if existingJobs[completed[i].Spec.JobName] {
+			continue
+		} else if retention.MaxAge != nil && age > retention.MaxAge.Duration {
+			expired = append(expired, completed[i])
+		} else if retention.MaxCount != nil && i >= int(*retention.MaxCount) {
+			expired = append(expired, completed[i])
+		} else if retention.MaxAge != nil {
+			// The records kept are sorted, the last one expires first
+			nextExpiry = retention.MaxAge.Duration - age
+		}
+	}
+	return expired, nextExpiry
+}
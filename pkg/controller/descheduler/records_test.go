@@ -0,0 +1,69 @@
+package descheduler
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	deschedulerv1beta1 "github.com/skckadiyala/descheduler-operator/pkg/apis/descheduler/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestExpiredRunRecords(t *testing.T) {
+	now := time.Now()
+	record := func(name string, age time.Duration) deschedulerv1beta1.DeschedulerRun {
+		run := deschedulerv1beta1.DeschedulerRun{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec:       deschedulerv1beta1.DeschedulerRunSpec{JobName: name},
+		}
+		if age >= 0 {
+			completionTime := metav1.NewTime(now.Add(-age))
+			run.Status.CompletionTime = &completionTime
+		}
+		return run
+	}
+	records := []deschedulerv1beta1.DeschedulerRun{
+		record("active", -1),
+		record("3h", 3*time.Hour),
+		record("1h", time.Hour),
+		record("2h", 2*time.Hour),
+		record("10m", 10*time.Minute),
+	}
+	maxCount := func(count int32) *int32 { return &count }
+	maxAge := func(d time.Duration) *metav1.Duration { return &metav1.Duration{Duration: d} }
+
+	tests := []struct {
+		name         string
+		retention    deschedulerv1beta1.RunRetention
+		existingJobs []string
+		expired      []string
+		nextExpiry   time.Duration
+	}{
+		{"count only", deschedulerv1beta1.RunRetention{MaxCount: maxCount(2)}, nil, []string{"2h", "3h"}, 0},
+		{"age only", deschedulerv1beta1.RunRetention{MaxAge: maxAge(150 * time.Minute)}, nil, []string{"3h"}, 30 * time.Minute},
+		{"count and age", deschedulerv1beta1.RunRetention{MaxCount: maxCount(1), MaxAge: maxAge(time.Hour)}, nil, []string{"1h", "2h", "3h"}, 50 * time.Minute},
+		{"nothing expired", deschedulerv1beta1.RunRetention{MaxCount: maxCount(10), MaxAge: maxAge(4 * time.Hour)}, nil, nil, time.Hour},
+		// The cron job keeps its last successful jobs, their records would be created again
+		{"count with existing jobs", deschedulerv1beta1.RunRetention{MaxCount: maxCount(1)}, []string{"1h", "2h"}, []string{"3h"}, 0},
+		{"age with an existing job", deschedulerv1beta1.RunRetention{MaxAge: maxAge(90 * time.Minute)}, []string{"3h"}, []string{"2h"}, 30 * time.Minute},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			existingJobs := map[string]bool{}
+			for _, job := range test.existingJobs {
+				existingJobs[job] = true
+			}
+			expired, nextExpiry := expiredRunRecords(records, &test.retention, existingJobs, now)
+			var names []string
+			for _, record := range expired {
+				names = append(names, record.Name)
+			}
+			if !reflect.DeepEqual(names, test.expired) {
+				t.Errorf("expected %v expired, got %v", test.expired, names)
+			}
+			if nextExpiry != test.nextExpiry {
+				t.Errorf("expected the next record to expire in %v, got %v", test.nextExpiry, nextExpiry)
+			}
+		})
+	}
+}
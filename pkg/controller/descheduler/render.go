@@ -0,0 +1,45 @@
+package descheduler
+
+import (
+	"fmt"
+	"strings"
+
+	deschedulerv1beta1 "github.com/skckadiyala/descheduler-operator/pkg/apis/descheduler/v1beta1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// Render returns the ConfigMap and the CronJob, Deployment or Job the operator creates for the Descheduler, without
+// a cluster connection. scheme must know the Descheduler kind to set the owner references.
+func Render(descheduler *deschedulerv1beta1.Descheduler, scheme *runtime.Scheme) ([]runtime.Object, error) {
+	descheduler.Default()
+	if err := descheduler.Validate(); err != nil {
+		return nil, err
+	}
+	r := &ReconcileDescheduler{scheme: scheme}
+	if policy := descheduler.Spec.Policy; policy != nil && policy.ConfigMapRef != nil {
+		return nil, fmt.Errorf("the policy of ConfigMap %s can't be read without a cluster, embed it in spec.policy.raw", policy.ConfigMapRef.Name)
+	}
+	if err := r.resolvePolicy(descheduler); err != nil {
+		return nil, err
+	}
+	if unsupported, _ := UnsupportedSettings(descheduler.Spec); len(unsupported) != 0 {
+		return nil, fmt.Errorf("the descheduler image doesn't support the spec, %s: %s", descheduler.Spec.Image, strings.Join(unsupported, ", "))
+	}
+	cm, err := r.createConfigMap(descheduler)
+	if err != nil {
+		return nil, err
+	}
+	var workload runtime.Object
+	switch descheduler.Spec.Mode {
+	case deschedulerv1beta1.ModeDeployment:
+		workload, err = r.createDeployment(descheduler)
+	case deschedulerv1beta1.ModeJob:
+		workload, err = r.createRunJob(descheduler)
+	default:
+		workload, err = r.createCronJob(descheduler)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return []runtime.Object{cm, workload}, nil
+}
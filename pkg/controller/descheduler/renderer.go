@@ -0,0 +1,65 @@
+package descheduler
+
+import (
+	"fmt"
+
+	deschedulerv1beta1 "github.com/skckadiyala/descheduler-operator/pkg/apis/descheduler/v1beta1"
+	"gopkg.in/yaml.v2"
+)
+
+// PolicyRenderer renders the policy.yaml of a Descheduler in an API version of the descheduler policy
+type PolicyRenderer interface {
+	// APIVersion is the apiVersion of the policies the renderer emits
+	APIVersion() string
+	// Render returns the policy.yaml of the spec, which passed validation
+	Render(spec deschedulerv1beta1.DeschedulerSpec) (string, error)
+}
+
+// policyRenderers are the renderers of the policy versions a Descheduler can request
+var policyRenderers = map[deschedulerv1beta1.PolicyVersion]PolicyRenderer{
+	deschedulerv1beta1.PolicyVersionV1alpha1: v1alpha1Renderer{},
+	deschedulerv1beta1.PolicyVersionV1alpha2: v1alpha2Renderer{},
+}
+
+// RendererFor returns the renderer of the policy version of the spec, picked from the release of the image when
+// spec.policyVersion is unset
+func RendererFor(spec deschedulerv1beta1.DeschedulerSpec) (PolicyRenderer, error) {
+	version := policyVersion(spec)
+	renderer, ok := policyRenderers[version]
+	if !ok {
+		return nil, fmt.Errorf("no renderer for policy version %s", version)
+	}
+	return renderer, nil
+}
+
+// v1alpha1Renderer renders the strategies of a descheduler/v1alpha1 policy
+type v1alpha1Renderer struct{}
+
+func (v1alpha1Renderer) APIVersion() string {
+	return "descheduler/v1alpha1"
+}
+
+func (v1alpha1Renderer) Render(spec deschedulerv1beta1.DeschedulerSpec) (string, error) {
+	policy := GeneratePolicy(spec)
+	return marshalPolicy(&policy)
+}
+
+// v1alpha2Renderer renders the profiles and plugins of a descheduler/v1alpha2 policy
+type v1alpha2Renderer struct{}
+
+func (v1alpha2Renderer) APIVersion() string {
+	return "descheduler/v1alpha2"
+}
+
+func (v1alpha2Renderer) Render(spec deschedulerv1beta1.DeschedulerSpec) (string, error) {
+	policy := GeneratePolicyV1alpha2(spec)
+	return marshalPolicy(&policy)
+}
+
+func marshalPolicy(policy interface{}) (string, error) {
+	policyContent, err := yaml.Marshal(policy)
+	if err != nil {
+		return "", fmt.Errorf("error marshalling descheduler policy %v", err)
+	}
+	return string(policyContent), nil
+}
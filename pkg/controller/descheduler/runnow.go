@@ -0,0 +1,98 @@
+package descheduler
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log"
+
+	deschedulerv1beta1 "github.com/skckadiyala/descheduler-operator/pkg/apis/descheduler/v1beta1"
+	batch "k8s.io/api/batch/v1"
+	batchv1beta1 "k8s.io/api/batch/v1beta1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// manualInstantiateAnnotation is set by kubectl create job --from=cronjob on the jobs it creates
+const manualInstantiateAnnotation = "cronjob.kubernetes.io/instantiate"
+
+// triggerManualRun creates a one-off job from the template of the cron job when the RunNowAnnotation of the
+// Descheduler is set to a token not run yet. In CronJob mode the job is owned by cronJob like the scheduled ones, so
+// it shows in the run history and gets a DeschedulerRun. In Deployment mode the job runs once, without the
+// descheduling-interval flag. A run that can't be created is recorded as skipped with the reason.
+func (r *ReconcileDescheduler) triggerManualRun(descheduler *deschedulerv1beta1.Descheduler, cronJob *batchv1beta1.CronJob) error {
+	token := descheduler.Annotations[deschedulerv1beta1.RunNowAnnotation]
+	if token == "" || (descheduler.Status.LastManualRun != nil && descheduler.Status.LastManualRun.Token == token) {
+		return nil
+	}
+
+	if descheduler.Spec.Suspend {
+		// Suspending freezes evictions, the token is consumed so the run doesn't happen on resume
+		r.skipManualRun(descheduler, token, "Descheduler is suspended")
+		return nil
+	}
+
+	// The job is owned by the cron job in CronJob mode, by the Descheduler in the other modes
+	var owner metav1.Object = descheduler
+	if descheduler.Spec.Mode == deschedulerv1beta1.ModeCronJob {
+		owner = cronJob
+	}
+	template, err := deschedulerJobTemplate(descheduler)
+	if err != nil {
+		return err
+	}
+
+	sum := sha256.Sum256([]byte(token))
+	job := &batch.Job{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Job",
+			APIVersion: batch.SchemeGroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        fmt.Sprintf("%s-run-%s", descheduler.Name, hex.EncodeToString(sum[:])[:10]),
+			Namespace:   descheduler.Namespace,
+			Labels:      template.Labels,
+			Annotations: map[string]string{},
+		},
+		Spec: template.Spec,
+	}
+	for key, value := range template.Annotations {
+		job.Annotations[key] = value
+	}
+	job.Annotations[manualInstantiateAnnotation] = "manual"
+	job.Annotations[deschedulerv1beta1.RunNowAnnotation] = token
+	if err := controllerutil.SetControllerReference(owner, job, r.scheme); err != nil {
+		return fmt.Errorf("error setting owner references %v", err)
+	}
+
+	log.Printf("Creating job %s/%s for run-now token %s", job.Namespace, job.Name, token)
+	err = r.client.Create(context.TODO(), job)
+	if err != nil && !errors.IsAlreadyExists(err) {
+		log.Printf(" error while creating job %v", err)
+		return err
+	}
+	r.recorder.Eventf(descheduler, v1.EventTypeNormal, "ManualRunTriggered", "Created job %s for run-now token %s", job.Name, token)
+	descheduler.Status.LastManualRun = &deschedulerv1beta1.ManualRun{
+		Token:       token,
+		JobName:     job.Name,
+		TriggeredAt: metav1.Now(),
+		Result:      deschedulerv1beta1.RunActive,
+	}
+	return nil
+}
+
+// skipManualRun consumes the run-now token without running descheduler, the reason is recorded in the status and
+// in an event so the token can be set again once it is solved
+func (r *ReconcileDescheduler) skipManualRun(descheduler *deschedulerv1beta1.Descheduler, token, reason string) {
+	log.Printf("%s, skip run-now token %s of descheduler %s/%s", reason, token, descheduler.Namespace, descheduler.Name)
+	r.recorder.Eventf(descheduler, v1.EventTypeWarning, "ManualRunSkipped", "%s, skipped run-now token %s", reason, token)
+	descheduler.Status.LastManualRun = &deschedulerv1beta1.ManualRun{
+		Token:       token,
+		TriggeredAt: metav1.Now(),
+		Result:      deschedulerv1beta1.RunSkipped,
+		Reason:      reason,
+	}
+}
@@ -0,0 +1,176 @@
+package descheduler
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	deschedulerv1beta1 "github.com/skckadiyala/descheduler-operator/pkg/apis/descheduler/v1beta1"
+	batch "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// updateRunHistory records the jobs created by the cron job of the Descheduler in its status, the last
+// spec.runHistoryLimit runs are kept after the CronJob deleted their Job
+func (r *ReconcileDescheduler) updateRunHistory(descheduler *deschedulerv1beta1.Descheduler, jobs []batch.Job) error {
+	runs := map[string]deschedulerv1beta1.RunSummary{}
+	for _, run := range descheduler.Status.Runs {
+		if run.Result == deschedulerv1beta1.RunActive {
+			// The job of an active run was deleted before it finished, unless listed below
+			run.Result = deschedulerv1beta1.RunFailed
+			if run.FailureReason == "" {
+				run.FailureReason = "JobDeleted"
+			}
+		}
+		runs[run.JobName] = run
+	}
+	for i := range jobs {
+		run := runFromJob(&jobs[i])
+		pod, err := r.jobPod(&jobs[i])
+		if err != nil {
+			return err
+		}
+		if pod != nil {
+			run.PodName = pod.Name
+			if reason := imagePullFailure(pod); reason != "" {
+				run.FailureReason = reason
+			}
+		}
+		if existing, ok := runs[run.JobName]; ok {
+			if existing.Result == deschedulerv1beta1.RunSuperseded {
+				// The job is being deleted
+				continue
+			}
+			// Keep what was learnt from the pod once it is deleted
+			if run.PodName == "" {
+				run.PodName = existing.PodName
+			}
+			if isImagePullFailure(existing.FailureReason) && run.Result != deschedulerv1beta1.RunSucceeded {
+				run.FailureReason = existing.FailureReason
+			}
+		}
+		runs[run.JobName] = run
+	}
+
+	history := make([]deschedulerv1beta1.RunSummary, 0, len(runs))
+	for _, run := range runs {
+		history = append(history, run)
+		if run.Result == deschedulerv1beta1.RunSucceeded && run.CompletionTime != nil &&
+			(descheduler.Status.LastSuccessfulTime == nil || descheduler.Status.LastSuccessfulTime.Before(run.CompletionTime)) {
+			descheduler.Status.LastSuccessfulTime = run.CompletionTime.DeepCopy()
+		}
+	}
+	sort.Slice(history, func(i, j int) bool { return runBefore(history[i], history[j]) })
+	limit := deschedulerv1beta1.DefaultRunHistoryLimit
+	if descheduler.Spec.RunHistoryLimit != nil {
+		limit = *descheduler.Spec.RunHistoryLimit
+	}
+	if len(history) > int(limit) {
+		history = history[:limit]
+	}
+	if len(history) == 0 {
+		history = nil
+	}
+	descheduler.Status.Runs = history
+
+	if manualRun := descheduler.Status.LastManualRun; manualRun != nil {
+		if run, ok := runs[manualRun.JobName]; ok {
+			manualRun.Result = run.Result
+		}
+	}
+	return nil
+}
+
+// runFinished returns true when the run succeeded or failed, superseded runs were deleted before they finished
+func runFinished(run *deschedulerv1beta1.RunSummary) bool {
+	return run.Result == deschedulerv1beta1.RunSucceeded || run.Result == deschedulerv1beta1.RunFailed
+}
+
+// supersedeRun records that the job of an active run was deleted because a job with the new spec replaces it, so
+// it isn't recorded as failed once it disappears
+func supersedeRun(descheduler *deschedulerv1beta1.Descheduler, jobName string) {
+	for i := range descheduler.Status.Runs {
+		if run := &descheduler.Status.Runs[i]; run.JobName == jobName && run.Result == deschedulerv1beta1.RunActive {
+			now := metav1.Now()
+			run.Result = deschedulerv1beta1.RunSuperseded
+			run.CompletionTime = &now
+		}
+	}
+}
+
+// runBefore orders runs most recent first, runs not started yet come first
+func runBefore(a, b deschedulerv1beta1.RunSummary) bool {
+	switch {
+	case a.StartTime == nil && b.StartTime == nil:
+		return a.JobName > b.JobName
+	case a.StartTime == nil:
+		return true
+	case b.StartTime == nil:
+		return false
+	case a.StartTime.Equal(b.StartTime):
+		return a.JobName > b.JobName
+	}
+	return b.StartTime.Before(a.StartTime)
+}
+
+// runFromJob returns the run described by the job, without its pod name
+func runFromJob(job *batch.Job) deschedulerv1beta1.RunSummary {
+	run := deschedulerv1beta1.RunSummary{
+		JobName:   job.Name,
+		StartTime: job.Status.StartTime,
+		Result:    deschedulerv1beta1.RunActive,
+	}
+	if container := findContainer(job.Spec.Template.Spec.Containers, DeschedulerContainerName); container != nil {
+		run.Image = container.Image
+	}
+	_, run.Manual = job.Annotations[deschedulerv1beta1.RunNowAnnotation]
+	run.DryRun = isDryRunJob(job)
+	for _, condition := range job.Status.Conditions {
+		if condition.Status != v1.ConditionTrue {
+			continue
+		}
+		switch condition.Type {
+		case batch.JobComplete:
+			run.Result = deschedulerv1beta1.RunSucceeded
+			run.CompletionTime = job.Status.CompletionTime
+		case batch.JobFailed:
+			// Failed jobs have no completion time, the condition tells when it failed
+			run.Result = deschedulerv1beta1.RunFailed
+			run.FailureReason = condition.Reason
+		default:
+			continue
+		}
+		if run.CompletionTime == nil {
+			run.CompletionTime = &condition.LastTransitionTime
+		}
+		run.CompletionTime = run.CompletionTime.DeepCopy()
+		if run.StartTime != nil {
+			run.Duration = &metav1.Duration{Duration: run.CompletionTime.Sub(run.StartTime.Time)}
+		}
+	}
+	if run.StartTime != nil {
+		run.StartTime = run.StartTime.DeepCopy()
+	}
+	return run
+}
+
+// jobPod returns the last pod created by the job, or nil
+func (r *ReconcileDescheduler) jobPod(job *batch.Job) (*v1.Pod, error) {
+	pods := &v1.PodList{}
+	err := r.client.List(context.TODO(), client.InNamespace(job.Namespace).MatchingLabels(map[string]string{"job-name": job.Name}), pods)
+	if err != nil {
+		return nil, fmt.Errorf("error listing pods of job %s %v", job.Name, err)
+	}
+	var last *v1.Pod
+	for i := range pods.Items {
+		if !metav1.IsControlledBy(&pods.Items[i], job) {
+			continue
+		}
+		if last == nil || last.CreationTimestamp.Before(&pods.Items[i].CreationTimestamp) {
+			last = &pods.Items[i]
+		}
+	}
+	return last, nil
+}
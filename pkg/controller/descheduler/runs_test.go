@@ -0,0 +1,32 @@
+package descheduler
+
+import (
+	"testing"
+
+	deschedulerv1beta1 "github.com/skckadiyala/descheduler-operator/pkg/apis/descheduler/v1beta1"
+)
+
+func TestDeletedRunsOfTheHistory(t *testing.T) {
+	descheduler := testDescheduler()
+	descheduler.Spec.Mode = deschedulerv1beta1.ModeJob
+	descheduler.Status.Runs = []deschedulerv1beta1.RunSummary{
+		{JobName: "example-descheduler-new", Result: deschedulerv1beta1.RunActive},
+		{JobName: "example-descheduler-old", Result: deschedulerv1beta1.RunActive},
+	}
+	supersedeRun(descheduler, "example-descheduler-old")
+
+	r := &ReconcileDescheduler{}
+	if err := r.updateRunHistory(descheduler, nil); err != nil {
+		t.Fatalf("updateRunHistory: %v", err)
+	}
+	results := map[string]deschedulerv1beta1.RunSummary{}
+	for _, run := range descheduler.Status.Runs {
+		results[run.JobName] = run
+	}
+	if run := results["example-descheduler-old"]; run.Result != deschedulerv1beta1.RunSuperseded || run.CompletionTime == nil {
+		t.Errorf("job deleted for a spec change: expected a completed Superseded run, got %+v", run)
+	}
+	if run := results["example-descheduler-new"]; run.Result != deschedulerv1beta1.RunFailed || run.FailureReason != "JobDeleted" {
+		t.Errorf("job deleted by someone else: expected a Failed JobDeleted run, got %+v", run)
+	}
+}
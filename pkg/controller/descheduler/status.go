@@ -0,0 +1,264 @@
+package descheduler
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"reflect"
+
+	deschedulerv1beta1 "github.com/skckadiyala/descheduler-operator/pkg/apis/descheduler/v1beta1"
+	appsv1 "k8s.io/api/apps/v1"
+	batch "k8s.io/api/batch/v1"
+	batchv1beta1 "k8s.io/api/batch/v1beta1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// Reasons of the conditions set by the operator
+const (
+	ReasonInvalidSpec           = "InvalidSpec"
+	ReasonValid                 = "Valid"
+	ReasonConfigMapFailed       = "ConfigMapFailed"
+	ReasonCronJobFailed         = "CronJobFailed"
+	ReasonCronJobPending        = "CronJobPending"
+	ReasonCronJobScheduled      = "CronJobScheduled"
+	ReasonNoRunFinished         = "NoRunFinished"
+	ReasonJobSucceeded          = "JobSucceeded"
+	ReasonJobFailed             = "JobFailed"
+	ReasonReconcileSucceeded    = "ReconcileSucceeded"
+	ReasonUpdating              = "Updating"
+	ReasonSuspended             = "Suspended"
+	ReasonNotSuspended          = "NotSuspended"
+	ReasonDeploymentPending     = "DeploymentPending"
+	ReasonDeploymentAvailable   = "DeploymentAvailable"
+	ReasonDeploymentUnavailable = "DeploymentUnavailable"
+	ReasonJobPending            = "JobPending"
+	ReasonJobCreated            = "JobCreated"
+)
+
+// setCondition sets a condition of the Descheduler for its current generation
+func setCondition(descheduler *deschedulerv1beta1.Descheduler, conditionType deschedulerv1beta1.DeschedulerConditionType,
+	status v1.ConditionStatus, reason, message string) {
+	descheduler.Status.SetCondition(deschedulerv1beta1.DeschedulerCondition{
+		Type:               conditionType,
+		Status:             status,
+		ObservedGeneration: descheduler.Generation,
+		Reason:             reason,
+		Message:            message,
+	})
+}
+
+// setWorkloadCondition sets the WorkloadReady condition. CronJobReady is kept up to date in CronJob mode for the
+// clients still reading it, and removed in the other modes.
+func setWorkloadCondition(descheduler *deschedulerv1beta1.Descheduler, status v1.ConditionStatus, reason, message string) {
+	setCondition(descheduler, deschedulerv1beta1.ConditionWorkloadReady, status, reason, message)
+	if descheduler.Spec.Mode == deschedulerv1beta1.ModeCronJob {
+		setCondition(descheduler, deschedulerv1beta1.ConditionCronJobReady, status, reason, message)
+	} else {
+		descheduler.Status.RemoveCondition(deschedulerv1beta1.ConditionCronJobReady)
+	}
+}
+
+// degraded marks the Descheduler as degraded because of err and returns err so the request is requeued, conflicts
+// set the Conflict condition instead
+func (r *ReconcileDescheduler) degraded(descheduler *deschedulerv1beta1.Descheduler, oldStatus *deschedulerv1beta1.DeschedulerStatus,
+	reason string, err error) (reconcile.Result, error) {
+	if conflict, ok := err.(*conflictError); ok {
+		return r.conflict(descheduler, oldStatus, conflict)
+	}
+	setCondition(descheduler, deschedulerv1beta1.ConditionDegraded, v1.ConditionTrue, reason, err.Error())
+	setCondition(descheduler, deschedulerv1beta1.ConditionReady, v1.ConditionFalse, reason, err.Error())
+	if statusErr := r.updateDeschedulerStatus(descheduler, oldStatus); statusErr != nil {
+		log.Printf("Failed to record degraded status %v", statusErr)
+	}
+	return reconcile.Result{}, err
+}
+
+// updateDeschedulerStatus writes the status of the Descheduler through the status subresource when it differs
+// from oldStatus
+func (r *ReconcileDescheduler) updateDeschedulerStatus(descheduler *deschedulerv1beta1.Descheduler, oldStatus *deschedulerv1beta1.DeschedulerStatus) error {
+	descheduler.Status.ObservedGeneration = descheduler.Generation
+	if reflect.DeepEqual(oldStatus, &descheduler.Status) {
+		return nil
+	}
+	log.Printf("Updating descheduler status ")
+	err := r.client.Status().Update(context.TODO(), descheduler)
+	if err != nil {
+		log.Printf("Failed to update descheduler status %v", err)
+		return err
+	}
+	return nil
+}
+
+// updateCronJobConditions sets the WorkloadReady and LastRunSucceeded conditions, the run history, the run records
+// and the eviction summary from the CronJob, Deployment or Job of the Descheduler and the Jobs it created. It returns
+// whether the workload of the mode exists.
+func (r *ReconcileDescheduler) updateCronJobConditions(descheduler *deschedulerv1beta1.Descheduler) (bool, error) {
+	var cronJob *batchv1beta1.CronJob
+	var ready bool
+	var err error
+	switch descheduler.Spec.Mode {
+	case deschedulerv1beta1.ModeDeployment:
+		ready, err = r.updateDeploymentCondition(descheduler)
+	case deschedulerv1beta1.ModeJob:
+		ready, err = r.updateJobCondition(descheduler)
+	default:
+		cronJob, err = r.updateCronJobCondition(descheduler)
+		ready = cronJob != nil
+	}
+	if err != nil || !ready {
+		return ready, err
+	}
+
+	jobs := &batch.JobList{}
+	if err := r.client.List(context.TODO(), client.InNamespace(descheduler.Namespace), jobs); err != nil {
+		return true, err
+	}
+	// Jobs are created by the cron job, or by the operator for Job mode and run-now outside CronJob mode
+	ownedJobs := make([]batch.Job, 0, len(jobs.Items))
+	for _, job := range jobs.Items {
+		if (cronJob != nil && metav1.IsControlledBy(&job, cronJob)) || metav1.IsControlledBy(&job, descheduler) {
+			ownedJobs = append(ownedJobs, job)
+		}
+	}
+	if descheduler.Spec.Suspend && descheduler.Spec.TerminateActiveRuns {
+		if err := r.terminateActiveRuns(descheduler, ownedJobs); err != nil {
+			return true, err
+		}
+	}
+	if err := r.updateRunHistory(descheduler, ownedJobs); err != nil {
+		return true, err
+	}
+	evictions, err := r.updateRunRecords(descheduler, ownedJobs)
+	if err != nil {
+		return true, err
+	}
+	r.updateEvictionSummary(descheduler, evictions)
+	if err := r.updateDryRunReport(descheduler, evictions); err != nil {
+		return true, err
+	}
+
+	var lastRun *deschedulerv1beta1.RunSummary
+	for i := range descheduler.Status.Runs {
+		if runFinished(&descheduler.Status.Runs[i]) {
+			lastRun = &descheduler.Status.Runs[i]
+			break
+		}
+	}
+	switch {
+	case lastRun == nil:
+		setCondition(descheduler, deschedulerv1beta1.ConditionLastRunSucceeded, v1.ConditionUnknown, ReasonNoRunFinished,
+			"no descheduler job finished yet")
+	case lastRun.Result == deschedulerv1beta1.RunSucceeded:
+		setCondition(descheduler, deschedulerv1beta1.ConditionLastRunSucceeded, v1.ConditionTrue, ReasonJobSucceeded,
+			fmt.Sprintf("job %s completed", lastRun.JobName))
+	default:
+		setCondition(descheduler, deschedulerv1beta1.ConditionLastRunSucceeded, v1.ConditionFalse, ReasonJobFailed,
+			fmt.Sprintf("job %s failed", lastRun.JobName))
+	}
+	return true, nil
+}
+
+// terminateActiveRuns deletes the active jobs of a suspended Descheduler
+func (r *ReconcileDescheduler) terminateActiveRuns(descheduler *deschedulerv1beta1.Descheduler, jobs []batch.Job) error {
+	for i := range jobs {
+		if runFromJob(&jobs[i]).Result != deschedulerv1beta1.RunActive || jobs[i].DeletionTimestamp != nil {
+			continue
+		}
+		log.Printf("Descheduler %s/%s is suspended, deleting active job %s", descheduler.Namespace, descheduler.Name, jobs[i].Name)
+		err := r.client.Delete(context.TODO(), &jobs[i], client.PropagationPolicy(metav1.DeletePropagationBackground))
+		if err != nil && !errors.IsNotFound(err) {
+			return fmt.Errorf("error deleting job %s %v", jobs[i].Name, err)
+		}
+		r.recorder.Eventf(descheduler, v1.EventTypeNormal, "RunTerminated", "Descheduler is suspended, deleted active job %s", jobs[i].Name)
+	}
+	return nil
+}
+
+// updateCronJobCondition sets the WorkloadReady condition from the cron job of the Descheduler and returns the
+// cron job, nil when it doesn't exist yet
+func (r *ReconcileDescheduler) updateCronJobCondition(descheduler *deschedulerv1beta1.Descheduler) (*batchv1beta1.CronJob, error) {
+	cronJob := &batchv1beta1.CronJob{}
+	err := r.client.Get(context.TODO(), types.NamespacedName{Name: descheduler.Name, Namespace: descheduler.Namespace}, cronJob)
+	if err != nil && errors.IsNotFound(err) {
+		setWorkloadCondition(descheduler, v1.ConditionFalse, ReasonCronJobPending,
+			"waiting for the descheduler cron job to be created")
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	if cronJob.Spec.Suspend != nil && *cronJob.Spec.Suspend {
+		setWorkloadCondition(descheduler, v1.ConditionTrue, ReasonSuspended,
+			"descheduler cron job is suspended")
+	} else {
+		setWorkloadCondition(descheduler, v1.ConditionTrue, ReasonCronJobScheduled,
+			fmt.Sprintf("descheduler runs on schedule %q", cronJob.Spec.Schedule))
+	}
+
+	descheduler.Status.LastScheduleTime = cronJob.Status.LastScheduleTime.DeepCopy()
+	return cronJob, nil
+}
+
+// updateDeploymentCondition sets the WorkloadReady condition from the deployment of the Descheduler in Deployment mode
+func (r *ReconcileDescheduler) updateDeploymentCondition(descheduler *deschedulerv1beta1.Descheduler) (bool, error) {
+	deployment := &appsv1.Deployment{}
+	err := r.client.Get(context.TODO(), types.NamespacedName{Name: descheduler.Name, Namespace: descheduler.Namespace}, deployment)
+	if err != nil && errors.IsNotFound(err) {
+		setWorkloadCondition(descheduler, v1.ConditionFalse, ReasonDeploymentPending,
+			"waiting for the descheduler deployment to be created")
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	switch {
+	case descheduler.Spec.Suspend:
+		setWorkloadCondition(descheduler, v1.ConditionTrue, ReasonSuspended,
+			"descheduler deployment is scaled to 0")
+	case deployment.Status.AvailableReplicas == 0:
+		setWorkloadCondition(descheduler, v1.ConditionTrue, ReasonDeploymentUnavailable,
+			"descheduler deployment has no available pod")
+	default:
+		setWorkloadCondition(descheduler, v1.ConditionTrue, ReasonDeploymentAvailable,
+			fmt.Sprintf("descheduler runs every %s", deschedulingInterval(descheduler)))
+	}
+	return true, nil
+}
+
+// updateJobCondition sets the WorkloadReady condition from the job of the Descheduler in Job mode
+func (r *ReconcileDescheduler) updateJobCondition(descheduler *deschedulerv1beta1.Descheduler) (bool, error) {
+	if descheduler.Spec.Suspend {
+		setWorkloadCondition(descheduler, v1.ConditionTrue, ReasonSuspended,
+			"descheduler job is not created while suspended")
+		return true, nil
+	}
+	desired, err := r.createRunJob(descheduler)
+	if err != nil {
+		return false, err
+	}
+	job := &batch.Job{}
+	err = r.client.Get(context.TODO(), types.NamespacedName{Name: desired.Name, Namespace: descheduler.Namespace}, job)
+	if err != nil && errors.IsNotFound(err) {
+		setWorkloadCondition(descheduler, v1.ConditionFalse, ReasonJobPending,
+			"waiting for the descheduler job to be created")
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	setWorkloadCondition(descheduler, v1.ConditionTrue, ReasonJobCreated,
+		fmt.Sprintf("descheduler runs once in job %s", job.Name))
+	return true, nil
+}
+
+// deschedulingInterval returns the value of the descheduling-interval flag of the Descheduler
+func deschedulingInterval(descheduler *deschedulerv1beta1.Descheduler) string {
+	for _, flag := range descheduler.Spec.Flags {
+		if flag.Name == deschedulerv1beta1.FlagDeschedulingInterval {
+			return flag.Value
+		}
+	}
+	return ""
+}
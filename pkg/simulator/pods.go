@@ -0,0 +1,208 @@
+package simulator
+
+import (
+	"strconv"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+const (
+	// mirrorPodAnnotation is set by the kubelet on the mirror pods of static pods
+	mirrorPodAnnotation = "kubernetes.io/config.mirror"
+	// criticalPodAnnotation marks the critical pods of kube-system before priorities
+	criticalPodAnnotation = "scheduler.alpha.kubernetes.io/critical-pod"
+	// systemCriticalPriority is the lowest priority of the system-cluster-critical and system-node-critical classes
+	systemCriticalPriority = int32(2000000000)
+)
+
+// nodeReady returns true when the Ready condition of the node is true
+func nodeReady(node *v1.Node) bool {
+	for _, condition := range node.Status.Conditions {
+		if condition.Type == v1.NodeReady {
+			return condition.Status == v1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// isEvictable mirrors the checks of descheduler: pods without owner, mirror pods, critical pods, DaemonSet pods
+// and, unless allowed, pods with local storage are never evicted
+func isEvictable(pod *v1.Pod, evictLocalStoragePods bool) bool {
+	if len(pod.OwnerReferences) == 0 || isMirrorPod(pod) || isCriticalPod(pod) || isDaemonSetPod(pod) {
+		return false
+	}
+	return evictLocalStoragePods || !hasLocalStorage(pod)
+}
+
+func isMirrorPod(pod *v1.Pod) bool {
+	_, ok := pod.Annotations[mirrorPodAnnotation]
+	return ok
+}
+
+func isCriticalPod(pod *v1.Pod) bool {
+	if _, ok := pod.Annotations[criticalPodAnnotation]; ok && pod.Namespace == metav1.NamespaceSystem {
+		return true
+	}
+	return pod.Spec.Priority != nil && *pod.Spec.Priority >= systemCriticalPriority
+}
+
+func isDaemonSetPod(pod *v1.Pod) bool {
+	for _, owner := range pod.OwnerReferences {
+		if owner.Kind == "DaemonSet" {
+			return true
+		}
+	}
+	return false
+}
+
+func hasLocalStorage(pod *v1.Pod) bool {
+	for _, volume := range pod.Spec.Volumes {
+		if volume.HostPath != nil || volume.EmptyDir != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// podRequests returns the cpu and memory requested by the pod, the largest init container request counts when it
+// exceeds the sum of the containers
+func podRequests(pod *v1.Pod) v1.ResourceList {
+	requests := v1.ResourceList{}
+	for _, container := range pod.Spec.Containers {
+		for name, quantity := range container.Resources.Requests {
+			if total, ok := requests[name]; ok {
+				total.Add(quantity)
+				requests[name] = total
+			} else {
+				requests[name] = quantity.DeepCopy()
+			}
+		}
+	}
+	for _, container := range pod.Spec.InitContainers {
+		for name, quantity := range container.Resources.Requests {
+			if total, ok := requests[name]; !ok || quantity.Cmp(total) > 0 {
+				requests[name] = quantity.DeepCopy()
+			}
+		}
+	}
+	return requests
+}
+
+// qosClass returns the QoS class of the pod, computed from its containers when its status doesn't tell it
+func qosClass(pod *v1.Pod) v1.PodQOSClass {
+	if pod.Status.QOSClass != "" {
+		return pod.Status.QOSClass
+	}
+	requestsSet, guaranteed := false, true
+	for _, container := range pod.Spec.Containers {
+		if len(container.Resources.Requests) != 0 || len(container.Resources.Limits) != 0 {
+			requestsSet = true
+		}
+		for _, name := range []v1.ResourceName{v1.ResourceCPU, v1.ResourceMemory} {
+			limit, ok := container.Resources.Limits[name]
+			if !ok {
+				guaranteed = false
+				continue
+			}
+			if request, ok := container.Resources.Requests[name]; ok && request.Cmp(limit) != 0 {
+				guaranteed = false
+			}
+		}
+	}
+	switch {
+	case !requestsSet:
+		return v1.PodQOSBestEffort
+	case guaranteed:
+		return v1.PodQOSGuaranteed
+	default:
+		return v1.PodQOSBurstable
+	}
+}
+
+// podFitsNode returns true when the node matches the node selector and the required node affinity of the pod
+func podFitsNode(pod *v1.Pod, node *v1.Node) bool {
+	if !labels.SelectorFromSet(pod.Spec.NodeSelector).Matches(labels.Set(node.Labels)) {
+		return false
+	}
+	affinity := pod.Spec.Affinity
+	if affinity == nil || affinity.NodeAffinity == nil || affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution == nil {
+		return true
+	}
+	// The terms are ORed
+	for _, term := range affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms {
+		if nodeMatchesTerm(node, term) {
+			return true
+		}
+	}
+	return false
+}
+
+// nodeMatchesTerm returns true when the node matches all the requirements of the term, an empty term matches no node
+func nodeMatchesTerm(node *v1.Node, term v1.NodeSelectorTerm) bool {
+	if len(term.MatchExpressions) == 0 && len(term.MatchFields) == 0 {
+		return false
+	}
+	for _, requirement := range term.MatchExpressions {
+		if !requirementMatches(requirement, node.Labels) {
+			return false
+		}
+	}
+	for _, requirement := range term.MatchFields {
+		// metadata.name is the only field supported
+		if requirement.Key != "metadata.name" || !requirementMatches(requirement, map[string]string{requirement.Key: node.Name}) {
+			return false
+		}
+	}
+	return true
+}
+
+func requirementMatches(requirement v1.NodeSelectorRequirement, values map[string]string) bool {
+	value, exists := values[requirement.Key]
+	switch requirement.Operator {
+	case v1.NodeSelectorOpIn:
+		return exists && contains(requirement.Values, value)
+	case v1.NodeSelectorOpNotIn:
+		return !exists || !contains(requirement.Values, value)
+	case v1.NodeSelectorOpExists:
+		return exists
+	case v1.NodeSelectorOpDoesNotExist:
+		return !exists
+	case v1.NodeSelectorOpGt, v1.NodeSelectorOpLt:
+		if !exists || len(requirement.Values) != 1 {
+			return false
+		}
+		actual, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return false
+		}
+		expected, err := strconv.ParseInt(requirement.Values[0], 10, 64)
+		if err != nil {
+			return false
+		}
+		if requirement.Operator == v1.NodeSelectorOpGt {
+			return actual > expected
+		}
+		return actual < expected
+	}
+	return false
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// quantityPercent returns quantity as a percentage of capacity
+func quantityPercent(quantity, capacity resource.Quantity) float64 {
+	if capacity.IsZero() {
+		return 0
+	}
+	return float64(quantity.MilliValue()) * 100 / float64(capacity.MilliValue())
+}
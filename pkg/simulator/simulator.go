@@ -0,0 +1,100 @@
+// Package simulator evaluates a descheduler policy against a snapshot of the Nodes and Pods of a cluster and
+// returns the pods descheduler would evict, without running the descheduler image. It follows the strategies of
+// descheduler v0.9: RemoveDuplicates, LowNodeUtilization, RemovePodsViolatingInterPodAntiAffinity and
+// RemovePodsViolatingNodeAffinity.
+package simulator
+
+import (
+	deschedulerv1beta1 "github.com/skckadiyala/descheduler-operator/pkg/apis/descheduler/v1beta1"
+	"github.com/skckadiyala/descheduler-operator/pkg/controller/descheduler"
+	v1 "k8s.io/api/core/v1"
+)
+
+// Strategy names, as logged by descheduler and counted in the eviction summaries
+const (
+	StrategyRemoveDuplicates                        = "RemoveDuplicates"
+	StrategyLowNodeUtilization                      = "LowNodeUtilization"
+	StrategyRemovePodsViolatingInterPodAntiAffinity = "RemovePodsViolatingInterPodAntiAffinity"
+	StrategyRemovePodsViolatingNodeAffinity         = "RemovePodsViolatingNodeAffinity"
+)
+
+// Simulate returns the pods the strategies enabled in policy would evict from the snapshot, in the order descheduler
+// runs them. A pod evicted by a strategy is not seen by the next ones. Pods with local storage are only evicted when
+// the policy sets evictLocalStoragePods.
+func Simulate(policy *descheduler.Policy, snapshot *Snapshot) []deschedulerv1beta1.EvictedPod {
+	state := newClusterState(snapshot, policy.EvictLocalStoragePods)
+	var evicted []deschedulerv1beta1.EvictedPod
+	if policy.Strategies.RemoveDuplicates.Enabled {
+		evicted = append(evicted, state.removeDuplicates()...)
+	}
+	if policy.Strategies.LowNodeUtilization.Enabled {
+		evicted = append(evicted, state.lowNodeUtilization(policy)...)
+	}
+	if policy.Strategies.RemovePodsViolatingInterPodAntiAffinity.Enabled {
+		evicted = append(evicted, state.removePodsViolatingInterPodAntiAffinity()...)
+	}
+	if policy.Strategies.RemovePodsViolatingNodeAffinity.Enabled {
+		for _, nodeAffinityType := range policy.Strategies.RemovePodsViolatingNodeAffinity.Params.NodeAffinityType {
+			// descheduler only supports requiredDuringSchedulingIgnoredDuringExecution
+			if nodeAffinityType == "requiredDuringSchedulingIgnoredDuringExecution" {
+				evicted = append(evicted, state.removePodsViolatingNodeAffinity()...)
+			}
+		}
+	}
+	return evicted
+}
+
+// clusterState is the snapshot being descheduled, pods are removed from it as they are evicted
+type clusterState struct {
+	// evictLocalStoragePods allows evicting pods using emptyDir or hostPath volumes
+	evictLocalStoragePods bool
+	// nodes are the ready nodes, in the order of the snapshot
+	nodes []*v1.Node
+	// pods are the pods running on each ready node
+	pods map[string][]*v1.Pod
+}
+
+func newClusterState(snapshot *Snapshot, evictLocalStoragePods bool) *clusterState {
+	state := &clusterState{evictLocalStoragePods: evictLocalStoragePods, pods: map[string][]*v1.Pod{}}
+	for i := range snapshot.Nodes {
+		if nodeReady(&snapshot.Nodes[i]) {
+			state.nodes = append(state.nodes, &snapshot.Nodes[i])
+		}
+	}
+	for i := range snapshot.Pods {
+		pod := &snapshot.Pods[i]
+		if pod.Spec.NodeName == "" || pod.Status.Phase == v1.PodSucceeded || pod.Status.Phase == v1.PodFailed {
+			continue
+		}
+		state.pods[pod.Spec.NodeName] = append(state.pods[pod.Spec.NodeName], pod)
+	}
+	return state
+}
+
+// evict removes the pod from its node and returns it as evicted by strategy
+func (s *clusterState) evict(pod *v1.Pod, strategy string) deschedulerv1beta1.EvictedPod {
+	pods := s.pods[pod.Spec.NodeName]
+	for i := range pods {
+		if pods[i] == pod {
+			s.pods[pod.Spec.NodeName] = append(pods[:i:i], pods[i+1:]...)
+			break
+		}
+	}
+	return deschedulerv1beta1.EvictedPod{
+		Name:      pod.Name,
+		Namespace: pod.Namespace,
+		Node:      pod.Spec.NodeName,
+		Strategy:  strategy,
+	}
+}
+
+// evictablePods returns the pods of the node descheduler is allowed to evict
+func (s *clusterState) evictablePods(node *v1.Node) []*v1.Pod {
+	var evictable []*v1.Pod
+	for _, pod := range s.pods[node.Name] {
+		if isEvictable(pod, s.evictLocalStoragePods) {
+			evictable = append(evictable, pod)
+		}
+	}
+	return evictable
+}
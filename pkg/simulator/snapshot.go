@@ -0,0 +1,103 @@
+package simulator
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Snapshot holds the Nodes and Pods of a cluster the simulation runs against
+type Snapshot struct {
+	Nodes []v1.Node
+	Pods  []v1.Pod
+}
+
+// SnapshotFromClient lists the Nodes and Pods of the cluster, from the cache of the manager when c is its client
+func SnapshotFromClient(c client.Client) (*Snapshot, error) {
+	nodes := &v1.NodeList{}
+	if err := c.List(context.TODO(), &client.ListOptions{}, nodes); err != nil {
+		return nil, fmt.Errorf("error listing nodes %v", err)
+	}
+	pods := &v1.PodList{}
+	if err := c.List(context.TODO(), &client.ListOptions{}, pods); err != nil {
+		return nil, fmt.Errorf("error listing pods %v", err)
+	}
+	return &Snapshot{Nodes: nodes.Items, Pods: pods.Items}, nil
+}
+
+// LoadSnapshot reads the Nodes and Pods of YAML or JSON files, e.g. saved with kubectl get nodes,pods -A -o yaml.
+// Files can hold several documents and Lists, other kinds are ignored.
+func LoadSnapshot(paths ...string) (*Snapshot, error) {
+	snapshot := &Snapshot{}
+	for _, path := range paths {
+		file, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		err = snapshot.read(file)
+		file.Close()
+		if err != nil {
+			return nil, fmt.Errorf("error reading %s %v", path, err)
+		}
+	}
+	return snapshot, nil
+}
+
+// object is the part of a manifest telling its kind, Lists hold their objects in items
+type object struct {
+	Kind  string            `json:"kind"`
+	Items []json.RawMessage `json:"items"`
+}
+
+// read adds the Nodes and Pods of the manifests read from r to the snapshot
+func (s *Snapshot) read(r io.Reader) error {
+	decoder := yaml.NewYAMLOrJSONDecoder(r, 4096)
+	for {
+		raw := json.RawMessage{}
+		if err := decoder.Decode(&raw); err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		if len(raw) == 0 || string(raw) == "null" {
+			continue
+		}
+		if err := s.add(raw); err != nil {
+			return err
+		}
+	}
+}
+
+func (s *Snapshot) add(raw json.RawMessage) error {
+	obj := object{}
+	if err := json.Unmarshal(raw, &obj); err != nil {
+		return err
+	}
+	switch obj.Kind {
+	case "List", "NodeList", "PodList":
+		for _, item := range obj.Items {
+			if err := s.add(item); err != nil {
+				return err
+			}
+		}
+	case "Node":
+		node := v1.Node{}
+		if err := json.Unmarshal(raw, &node); err != nil {
+			return err
+		}
+		s.Nodes = append(s.Nodes, node)
+	case "Pod":
+		pod := v1.Pod{}
+		if err := json.Unmarshal(raw, &pod); err != nil {
+			return err
+		}
+		s.Pods = append(s.Pods, pod)
+	}
+	return nil
+}
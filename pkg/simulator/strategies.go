@@ -0,0 +1,266 @@
+package simulator
+
+import (
+	"sort"
+	"strings"
+
+	deschedulerv1beta1 "github.com/skckadiyala/descheduler-operator/pkg/apis/descheduler/v1beta1"
+	"github.com/skckadiyala/descheduler-operator/pkg/controller/descheduler"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// removeDuplicates evicts the pods of the same owner running the same images on a node, but the first one
+func (s *clusterState) removeDuplicates() []deschedulerv1beta1.EvictedPod {
+	var evicted []deschedulerv1beta1.EvictedPod
+	for _, node := range s.nodes {
+		seen := map[string]bool{}
+		for _, pod := range s.evictablePods(node) {
+			images := make([]string, 0, len(pod.Spec.Containers))
+			for _, container := range pod.Spec.Containers {
+				images = append(images, container.Image)
+			}
+			sort.Strings(images)
+			duplicate := false
+			for _, owner := range pod.OwnerReferences {
+				key := strings.Join(append([]string{owner.Kind, owner.Name}, images...), "/")
+				duplicate = duplicate || seen[key]
+				seen[key] = true
+			}
+			if duplicate {
+				evicted = append(evicted, s.evict(pod, StrategyRemoveDuplicates))
+			}
+		}
+	}
+	return evicted
+}
+
+// nodeUsage is the usage of a node in percent of its allocatable cpu, memory and pods
+type nodeUsage struct {
+	node        *v1.Node
+	allocatable v1.ResourceList
+	requested   v1.ResourceList
+	percent     map[v1.ResourceName]float64
+}
+
+// thresholds returns the thresholds set in the policy, in percent
+func thresholds(cpu, memory, pods int) map[v1.ResourceName]float64 {
+	set := map[v1.ResourceName]float64{}
+	for name, value := range map[v1.ResourceName]int{v1.ResourceCPU: cpu, v1.ResourceMemory: memory, v1.ResourcePods: pods} {
+		if value > 0 {
+			set[name] = float64(value)
+		}
+	}
+	return set
+}
+
+func (s *clusterState) nodeUsage(node *v1.Node) *nodeUsage {
+	allocatable := node.Status.Capacity
+	if len(node.Status.Allocatable) != 0 {
+		allocatable = node.Status.Allocatable
+	}
+	usage := &nodeUsage{node: node, allocatable: allocatable, requested: v1.ResourceList{}}
+	for _, pod := range s.pods[node.Name] {
+		usage.add(pod, 1)
+	}
+	return usage
+}
+
+// add adds the requests of the pod to the usage, or removes them when sign is -1
+func (u *nodeUsage) add(pod *v1.Pod, sign int64) {
+	requests := podRequests(pod)
+	for _, name := range []v1.ResourceName{v1.ResourceCPU, v1.ResourceMemory} {
+		quantity := requests[name]
+		total := u.requested[name]
+		if sign > 0 {
+			total.Add(quantity)
+		} else {
+			total.Sub(quantity)
+		}
+		u.requested[name] = total
+	}
+	pods := u.requested[v1.ResourcePods]
+	u.requested[v1.ResourcePods] = *resource.NewQuantity(pods.Value()+sign, resource.DecimalSI)
+	u.percent = map[v1.ResourceName]float64{}
+	for name, quantity := range u.requested {
+		u.percent[name] = quantityPercent(quantity, u.allocatable[name])
+	}
+}
+
+// below returns true when the usage of every resource with a threshold is below it
+func (u *nodeUsage) below(thresholds map[v1.ResourceName]float64) bool {
+	for name, threshold := range thresholds {
+		if u.percent[name] >= threshold {
+			return false
+		}
+	}
+	return true
+}
+
+// above returns true when the usage of a resource with a threshold is above it
+func (u *nodeUsage) above(thresholds map[v1.ResourceName]float64) bool {
+	for name, threshold := range thresholds {
+		if u.percent[name] > threshold {
+			return true
+		}
+	}
+	return false
+}
+
+// lowNodeUtilization evicts pods from the nodes above the target thresholds while the nodes below the thresholds
+// have room for them, up to their target thresholds
+func (s *clusterState) lowNodeUtilization(policy *descheduler.Policy) []deschedulerv1beta1.EvictedPod {
+	params := policy.Strategies.LowNodeUtilization.Params.NodeResourceUtilizationThresholds
+	low := thresholds(params.Thresholds.CPU, params.Thresholds.Memory, params.Thresholds.Pods)
+	target := thresholds(params.TargetThresholds.CPU, params.TargetThresholds.Memory, params.TargetThresholds.Pods)
+	if len(low) == 0 || len(target) == 0 {
+		return nil
+	}
+
+	var lowNodes, targetNodes []*nodeUsage
+	for _, node := range s.nodes {
+		usage := s.nodeUsage(node)
+		if usage.below(low) && !node.Spec.Unschedulable {
+			lowNodes = append(lowNodes, usage)
+		} else if usage.above(target) {
+			targetNodes = append(targetNodes, usage)
+		}
+	}
+	if len(lowNodes) == 0 || len(lowNodes) < params.NumberOfNodes || len(lowNodes) == len(s.nodes) || len(targetNodes) == 0 {
+		return nil
+	}
+
+	// Room left on the underutilized nodes up to the target thresholds
+	room := map[v1.ResourceName]float64{}
+	for _, usage := range lowNodes {
+		for name, threshold := range target {
+			allocatable := usage.allocatable[name]
+			requested := usage.requested[name]
+			room[name] += float64(allocatable.MilliValue())*threshold/100 - float64(requested.MilliValue())
+		}
+	}
+
+	// Most utilized nodes first
+	sort.SliceStable(targetNodes, func(i, j int) bool {
+		return totalPercent(targetNodes[i]) > totalPercent(targetNodes[j])
+	})
+	var evicted []deschedulerv1beta1.EvictedPod
+	for _, usage := range targetNodes {
+		pods := s.evictablePods(usage.node)
+		// BestEffort pods are evicted first, Guaranteed pods last
+		sort.SliceStable(pods, func(i, j int) bool { return qosRank(pods[i]) < qosRank(pods[j]) })
+		for _, pod := range pods {
+			if !usage.above(target) || !hasRoom(room) {
+				break
+			}
+			requests := podRequests(pod)
+			for name := range room {
+				if name == v1.ResourcePods {
+					room[name] -= 1000
+				} else if quantity, ok := requests[name]; ok {
+					room[name] -= float64(quantity.MilliValue())
+				}
+			}
+			usage.add(pod, -1)
+			evicted = append(evicted, s.evict(pod, StrategyLowNodeUtilization))
+		}
+	}
+	return evicted
+}
+
+func hasRoom(room map[v1.ResourceName]float64) bool {
+	for _, left := range room {
+		if left <= 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func totalPercent(usage *nodeUsage) float64 {
+	total := 0.0
+	for _, percent := range usage.percent {
+		total += percent
+	}
+	return total
+}
+
+func qosRank(pod *v1.Pod) int {
+	switch qosClass(pod) {
+	case v1.PodQOSBestEffort:
+		return 0
+	case v1.PodQOSBurstable:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// removePodsViolatingInterPodAntiAffinity evicts the pods whose required anti-affinity matches another pod of their node
+func (s *clusterState) removePodsViolatingInterPodAntiAffinity() []deschedulerv1beta1.EvictedPod {
+	var evicted []deschedulerv1beta1.EvictedPod
+	for _, node := range s.nodes {
+		pods := s.evictablePods(node)
+		for i := 0; i < len(pods); i++ {
+			if antiAffinityViolated(pods[i], pods) {
+				evicted = append(evicted, s.evict(pods[i], StrategyRemovePodsViolatingInterPodAntiAffinity))
+				pods = append(pods[:i], pods[i+1:]...)
+				i--
+			}
+		}
+	}
+	return evicted
+}
+
+// antiAffinityViolated returns true when a pod other than pod matches one of its required anti-affinity terms
+func antiAffinityViolated(pod *v1.Pod, pods []*v1.Pod) bool {
+	affinity := pod.Spec.Affinity
+	if affinity == nil || affinity.PodAntiAffinity == nil {
+		return false
+	}
+	for _, term := range affinity.PodAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution {
+		namespaces := term.Namespaces
+		if len(namespaces) == 0 {
+			namespaces = []string{pod.Namespace}
+		}
+		selector, err := metav1.LabelSelectorAsSelector(term.LabelSelector)
+		if err != nil {
+			continue
+		}
+		for _, other := range pods {
+			if other != pod && contains(namespaces, other.Namespace) && selector.Matches(labels.Set(other.Labels)) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// removePodsViolatingNodeAffinity evicts the pods whose node doesn't match their required node affinity anymore,
+// when another schedulable node does
+func (s *clusterState) removePodsViolatingNodeAffinity() []deschedulerv1beta1.EvictedPod {
+	var evicted []deschedulerv1beta1.EvictedPod
+	for _, node := range s.nodes {
+		for _, pod := range s.evictablePods(node) {
+			affinity := pod.Spec.Affinity
+			if affinity == nil || affinity.NodeAffinity == nil || affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution == nil {
+				continue
+			}
+			if !podFitsNode(pod, node) && s.podFitsAnyNode(pod) {
+				evicted = append(evicted, s.evict(pod, StrategyRemovePodsViolatingNodeAffinity))
+			}
+		}
+	}
+	return evicted
+}
+
+func (s *clusterState) podFitsAnyNode(pod *v1.Pod) bool {
+	for _, node := range s.nodes {
+		if !node.Spec.Unschedulable && podFitsNode(pod, node) {
+			return true
+		}
+	}
+	return false
+}
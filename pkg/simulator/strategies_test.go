@@ -0,0 +1,240 @@
+package simulator
+
+import (
+	"reflect"
+	"testing"
+
+	deschedulerv1beta1 "github.com/skckadiyala/descheduler-operator/pkg/apis/descheduler/v1beta1"
+	"github.com/skckadiyala/descheduler-operator/pkg/controller/descheduler"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func testNode(name string, cpu string, labels map[string]string) v1.Node {
+	return v1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels},
+		Status: v1.NodeStatus{
+			Allocatable: v1.ResourceList{
+				v1.ResourceCPU:  resource.MustParse(cpu),
+				v1.ResourcePods: resource.MustParse("100"),
+			},
+			Conditions: []v1.NodeCondition{{Type: v1.NodeReady, Status: v1.ConditionTrue}},
+		},
+	}
+}
+
+// testPod returns a pod of the ReplicaSet owner running nginx on node, requesting cpu unless empty
+func testPod(name, node, owner, cpu string) v1.Pod {
+	pod := v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            name,
+			Namespace:       "default",
+			OwnerReferences: []metav1.OwnerReference{{Kind: "ReplicaSet", Name: owner}},
+		},
+		Spec: v1.PodSpec{
+			NodeName:   node,
+			Containers: []v1.Container{{Name: "nginx", Image: "nginx"}},
+		},
+		Status: v1.PodStatus{Phase: v1.PodRunning},
+	}
+	if cpu != "" {
+		pod.Spec.Containers[0].Resources.Requests = v1.ResourceList{v1.ResourceCPU: resource.MustParse(cpu)}
+	}
+	return pod
+}
+
+func withLabels(pod v1.Pod, labels map[string]string) v1.Pod {
+	pod.Labels = labels
+	return pod
+}
+
+func withAntiAffinity(pod v1.Pod, labels map[string]string) v1.Pod {
+	pod.Spec.Affinity = &v1.Affinity{PodAntiAffinity: &v1.PodAntiAffinity{
+		RequiredDuringSchedulingIgnoredDuringExecution: []v1.PodAffinityTerm{{
+			LabelSelector: &metav1.LabelSelector{MatchLabels: labels},
+			TopologyKey:   "kubernetes.io/hostname",
+		}},
+	}}
+	return pod
+}
+
+func withNodeAffinity(pod v1.Pod, key string, values ...string) v1.Pod {
+	pod.Spec.Affinity = &v1.Affinity{NodeAffinity: &v1.NodeAffinity{
+		RequiredDuringSchedulingIgnoredDuringExecution: &v1.NodeSelector{
+			NodeSelectorTerms: []v1.NodeSelectorTerm{{
+				MatchExpressions: []v1.NodeSelectorRequirement{{Key: key, Operator: v1.NodeSelectorOpIn, Values: values}},
+			}},
+		},
+	}}
+	return pod
+}
+
+func withEmptyDir(pod v1.Pod) v1.Pod {
+	pod.Spec.Volumes = []v1.Volume{{Name: "cache", VolumeSource: v1.VolumeSource{EmptyDir: &v1.EmptyDirVolumeSource{}}}}
+	return pod
+}
+
+func evictedPod(name, node, strategy string) deschedulerv1beta1.EvictedPod {
+	return deschedulerv1beta1.EvictedPod{Name: name, Namespace: "default", Node: node, Strategy: strategy}
+}
+
+func TestSimulate(t *testing.T) {
+	removeDuplicates := func(policy *descheduler.Policy) {
+		policy.Strategies.RemoveDuplicates.Enabled = true
+	}
+	lowNodeUtilization := func(thresholdCPU, targetThresholdCPU int) func(policy *descheduler.Policy) {
+		return func(policy *descheduler.Policy) {
+			strategy := &policy.Strategies.LowNodeUtilization
+			strategy.Enabled = true
+			strategy.Params.NodeResourceUtilizationThresholds.Thresholds.CPU = thresholdCPU
+			strategy.Params.NodeResourceUtilizationThresholds.TargetThresholds.CPU = targetThresholdCPU
+		}
+	}
+	antiAffinity := func(policy *descheduler.Policy) {
+		policy.Strategies.RemovePodsViolatingInterPodAntiAffinity.Enabled = true
+	}
+	nodeAffinity := func(policy *descheduler.Policy) {
+		policy.Strategies.RemovePodsViolatingNodeAffinity.Enabled = true
+		policy.Strategies.RemovePodsViolatingNodeAffinity.Params.NodeAffinityType = []string{"requiredDuringSchedulingIgnoredDuringExecution"}
+	}
+	web := map[string]string{"app": "web"}
+	unschedulable := testNode("node-b", "4", map[string]string{"zone": "b"})
+	unschedulable.Spec.Unschedulable = true
+
+	tests := []struct {
+		name     string
+		policy   func(policy *descheduler.Policy)
+		nodes    []v1.Node
+		pods     []v1.Pod
+		expected []deschedulerv1beta1.EvictedPod
+	}{
+		{
+			name:   "duplicates across owners",
+			policy: removeDuplicates,
+			nodes:  []v1.Node{testNode("node-a", "4", nil), testNode("node-b", "4", nil)},
+			pods: []v1.Pod{
+				testPod("web-1", "node-a", "web", ""),
+				testPod("web-2", "node-a", "web", ""),
+				testPod("api-1", "node-a", "api", ""),
+				testPod("web-3", "node-b", "web", ""),
+			},
+			expected: []deschedulerv1beta1.EvictedPod{evictedPod("web-2", "node-a", StrategyRemoveDuplicates)},
+		},
+		{
+			name:   "duplicates with other images or without owner",
+			policy: removeDuplicates,
+			nodes:  []v1.Node{testNode("node-a", "4", nil)},
+			pods: func() []v1.Pod {
+				redis := testPod("web-2", "node-a", "web", "")
+				redis.Spec.Containers[0].Image = "redis"
+				orphan := testPod("orphan-2", "node-a", "orphan", "")
+				orphan.OwnerReferences = nil
+				return []v1.Pod{testPod("web-1", "node-a", "web", ""), redis, testPod("orphan-1", "node-a", "orphan", ""), orphan}
+			}(),
+		},
+		{
+			name:   "duplicates with local storage",
+			policy: removeDuplicates,
+			nodes:  []v1.Node{testNode("node-a", "4", nil)},
+			pods:   []v1.Pod{withEmptyDir(testPod("web-1", "node-a", "web", "")), withEmptyDir(testPod("web-2", "node-a", "web", ""))},
+		},
+		{
+			name: "duplicates with local storage evicted by the policy",
+			policy: func(policy *descheduler.Policy) {
+				removeDuplicates(policy)
+				policy.EvictLocalStoragePods = true
+			},
+			nodes:    []v1.Node{testNode("node-a", "4", nil)},
+			pods:     []v1.Pod{withEmptyDir(testPod("web-1", "node-a", "web", "")), withEmptyDir(testPod("web-2", "node-a", "web", ""))},
+			expected: []deschedulerv1beta1.EvictedPod{evictedPod("web-2", "node-a", StrategyRemoveDuplicates)},
+		},
+		{
+			// node-a and node-c are above the target threshold, node-b has room for 1 cpu: the BestEffort pod is
+			// evicted first, then a single 1 cpu pod fills the room and nothing is evicted from node-c
+			name:   "low node utilization room",
+			policy: lowNodeUtilization(20, 25),
+			nodes:  []v1.Node{testNode("node-a", "4", nil), testNode("node-b", "4", nil), testNode("node-c", "4", nil)},
+			pods: []v1.Pod{
+				testPod("a-1", "node-a", "a-1", "1"),
+				testPod("a-2", "node-a", "a-2", "1"),
+				testPod("a-3", "node-a", "a-3", "1"),
+				testPod("a-4", "node-a", "a-4", ""),
+				testPod("c-1", "node-c", "c-1", "1500m"),
+			},
+			expected: []deschedulerv1beta1.EvictedPod{
+				evictedPod("a-4", "node-a", StrategyLowNodeUtilization),
+				evictedPod("a-1", "node-a", StrategyLowNodeUtilization),
+			},
+		},
+		{
+			// node-a goes back to the target threshold before the room of node-b is used
+			name:   "low node utilization target threshold",
+			policy: lowNodeUtilization(20, 50),
+			nodes:  []v1.Node{testNode("node-a", "4", nil), testNode("node-b", "8", nil)},
+			pods: []v1.Pod{
+				testPod("a-1", "node-a", "a-1", "1"),
+				testPod("a-2", "node-a", "a-2", "1"),
+				testPod("a-3", "node-a", "a-3", "1"),
+			},
+			expected: []deschedulerv1beta1.EvictedPod{evictedPod("a-1", "node-a", StrategyLowNodeUtilization)},
+		},
+		{
+			name:   "low node utilization without underutilized node",
+			policy: lowNodeUtilization(20, 50),
+			nodes:  []v1.Node{testNode("node-a", "4", nil), testNode("node-b", "4", nil)},
+			pods: []v1.Pod{
+				testPod("a-1", "node-a", "a-1", "3"),
+				testPod("b-1", "node-b", "b-1", "1"),
+			},
+		},
+		{
+			name:   "anti-affinity",
+			policy: antiAffinity,
+			nodes:  []v1.Node{testNode("node-a", "4", nil), testNode("node-b", "4", nil)},
+			pods: []v1.Pod{
+				withLabels(withAntiAffinity(testPod("web-1", "node-a", "web-1", ""), web), web),
+				withLabels(testPod("web-2", "node-a", "web-2", ""), web),
+				withAntiAffinity(testPod("cache-1", "node-b", "cache-1", ""), web),
+				func() v1.Pod {
+					pod := withLabels(testPod("web-3", "node-b", "web-3", ""), web)
+					pod.Namespace = "other"
+					return pod
+				}(),
+			},
+			expected: []deschedulerv1beta1.EvictedPod{evictedPod("web-1", "node-a", StrategyRemovePodsViolatingInterPodAntiAffinity)},
+		},
+		{
+			name:   "node affinity with a fitting node",
+			policy: nodeAffinity,
+			nodes: []v1.Node{
+				testNode("node-a", "4", map[string]string{"zone": "a"}),
+				testNode("node-b", "4", map[string]string{"zone": "b"}),
+			},
+			pods: []v1.Pod{
+				withNodeAffinity(testPod("web-1", "node-a", "web-1", ""), "zone", "b"),
+				withNodeAffinity(testPod("web-2", "node-a", "web-2", ""), "zone", "a"),
+			},
+			expected: []deschedulerv1beta1.EvictedPod{evictedPod("web-1", "node-a", StrategyRemovePodsViolatingNodeAffinity)},
+		},
+		{
+			name:   "node affinity without a fitting node",
+			policy: nodeAffinity,
+			nodes:  []v1.Node{testNode("node-a", "4", map[string]string{"zone": "a"}), unschedulable},
+			pods: []v1.Pod{
+				withNodeAffinity(testPod("web-1", "node-a", "web-1", ""), "zone", "b"),
+				withNodeAffinity(testPod("web-2", "node-a", "web-2", ""), "zone", "c"),
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			policy := &descheduler.Policy{}
+			test.policy(policy)
+			evicted := Simulate(policy, &Snapshot{Nodes: test.nodes, Pods: test.pods})
+			if !reflect.DeepEqual(evicted, test.expected) {
+				t.Errorf("expected evicted pods %+v\ngot                   %+v", test.expected, evicted)
+			}
+		})
+	}
+}
@@ -0,0 +1,10 @@
+package webhook
+
+import (
+	"github.com/skckadiyala/descheduler-operator/pkg/webhook/descheduler"
+)
+
+func init() {
+	// AddToServerFuncs is a list of functions to register webhooks with the server.
+	AddToServerFuncs = append(AddToServerFuncs, descheduler.Add)
+}
@@ -0,0 +1,280 @@
+package webhook
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Keys of the certificate secret, the ones of kubernetes.io/tls secrets and of cert-manager
+const (
+	secretCAKey   = "ca.crt"
+	secretCertKey = corev1.TLSCertKey
+	secretKeyKey  = corev1.TLSPrivateKeyKey
+)
+
+const (
+	// certValidity is how long the generated CA and serving certificate are valid
+	certValidity = 10 * 365 * 24 * time.Hour
+	// certRenewBefore is how long before its expiry a certificate is replaced when the operator starts
+	certRenewBefore = 30 * 24 * time.Hour
+	// caBundleResyncPeriod is how often the caBundles are set again, e.g. after the manifests were applied again
+	caBundleResyncPeriod = 10 * time.Minute
+)
+
+// CertOptions tell the Server to provision its serving certificate instead of expecting one in CertDir. The
+// certificate is kept in a Secret, generated with a self-signed CA when the Secret doesn't hold a valid one, and the
+// CA is set as the caBundle of the webhook configurations and of the CRD conversion webhook.
+type CertOptions struct {
+	// Namespace of the operator, of the Secret and of the webhook Service
+	Namespace string
+	// SecretName is the Secret holding ca.crt, tls.crt and tls.key
+	SecretName string
+	// ServiceName is the Service in front of the Server, the certificate is issued for its DNS names
+	ServiceName string
+	// WebhookConfigurationName is the name of the ValidatingWebhookConfiguration and of the
+	// MutatingWebhookConfiguration calling the Server
+	WebhookConfigurationName string
+	// CRDName is the CustomResourceDefinition whose conversion webhook is served by the Server
+	CRDName string
+}
+
+// certificate is a PEM encoded serving certificate, its key and the CA that signed it
+type certificate struct {
+	ca, cert, key []byte
+}
+
+// provisionCerts loads or generates the serving certificate and writes it in certDir for the Server, it returns the
+// CA the API server must trust
+func provisionCerts(c client.Client, options *CertOptions, certDir string) ([]byte, error) {
+	cert, err := loadOrGenerateCert(c, options)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(certDir, 0700); err != nil {
+		return nil, err
+	}
+	if err := ioutil.WriteFile(filepath.Join(certDir, secretCertKey), cert.cert, 0600); err != nil {
+		return nil, err
+	}
+	if err := ioutil.WriteFile(filepath.Join(certDir, secretKeyKey), cert.key, 0600); err != nil {
+		return nil, err
+	}
+	return cert.ca, nil
+}
+
+// setCABundles sets ca as the caBundle of the webhook configurations and of the CRD conversion webhook
+func setCABundles(c client.Client, options *CertOptions, ca []byte) error {
+	caBundle := base64.StdEncoding.EncodeToString(ca)
+	for _, kind := range []string{"ValidatingWebhookConfiguration", "MutatingWebhookConfiguration"} {
+		gvk := schema.GroupVersionKind{Group: "admissionregistration.k8s.io", Version: "v1beta1", Kind: kind}
+		err := patchCABundle(c, gvk, options.WebhookConfigurationName, caBundle, func(obj *unstructured.Unstructured) (bool, error) {
+			webhooks, _, err := unstructured.NestedSlice(obj.Object, "webhooks")
+			if err != nil {
+				return false, err
+			}
+			changed := false
+			for _, webhook := range webhooks {
+				if webhook, ok := webhook.(map[string]interface{}); ok {
+					if found, _, _ := unstructured.NestedString(webhook, "clientConfig", "caBundle"); found != caBundle {
+						changed = true
+						if err := unstructured.SetNestedField(webhook, caBundle, "clientConfig", "caBundle"); err != nil {
+							return false, err
+						}
+					}
+				}
+			}
+			return changed, unstructured.SetNestedSlice(obj.Object, webhooks, "webhooks")
+		})
+		if err != nil {
+			return err
+		}
+	}
+	gvk := schema.GroupVersionKind{Group: "apiextensions.k8s.io", Version: "v1beta1", Kind: "CustomResourceDefinition"}
+	return patchCABundle(c, gvk, options.CRDName, caBundle, func(obj *unstructured.Unstructured) (bool, error) {
+		path := []string{"spec", "conversion", "webhookClientConfig", "caBundle"}
+		if _, found, _ := unstructured.NestedMap(obj.Object, path[:3]...); !found {
+			return false, nil
+		}
+		if found, _, _ := unstructured.NestedString(obj.Object, path...); found == caBundle {
+			return false, nil
+		}
+		return true, unstructured.SetNestedField(obj.Object, caBundle, path...)
+	})
+}
+
+// patchCABundle updates the caBundle of a cluster scoped resource with set, the resource is skipped when it
+// doesn't exist so that the webhooks can be installed separately
+func patchCABundle(c client.Client, gvk schema.GroupVersionKind, name, caBundle string,
+	set func(*unstructured.Unstructured) (bool, error)) error {
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(gvk)
+	if err := c.Get(context.TODO(), types.NamespacedName{Name: name}, obj); err != nil {
+		if errors.IsNotFound(err) {
+			log.Info("Skipping the caBundle of a missing resource", "kind", gvk.Kind, "name", name)
+			return nil
+		}
+		return fmt.Errorf("unable to get %s %s: %v", gvk.Kind, name, err)
+	}
+	changed, err := set(obj)
+	if err != nil {
+		return fmt.Errorf("unable to set the caBundle of %s %s: %v", gvk.Kind, name, err)
+	}
+	if !changed {
+		return nil
+	}
+	log.Info("Setting the caBundle", "kind", gvk.Kind, "name", name)
+	if err := c.Update(context.TODO(), obj); err != nil {
+		return fmt.Errorf("unable to update the caBundle of %s %s: %v", gvk.Kind, name, err)
+	}
+	return nil
+}
+
+// loadOrGenerateCert returns the certificate of the Secret, or a new one stored in the Secret when it is missing,
+// about to expire or not issued for the Service
+func loadOrGenerateCert(c client.Client, options *CertOptions) (*certificate, error) {
+	secret := &corev1.Secret{}
+	err := c.Get(context.TODO(), types.NamespacedName{Name: options.SecretName, Namespace: options.Namespace}, secret)
+	if err != nil && !errors.IsNotFound(err) {
+		return nil, fmt.Errorf("unable to get secret %s: %v", options.SecretName, err)
+	}
+	exists := err == nil
+	dnsNames := serviceDNSNames(options.ServiceName, options.Namespace)
+	if exists {
+		cert := &certificate{ca: secret.Data[secretCAKey], cert: secret.Data[secretCertKey], key: secret.Data[secretKeyKey]}
+		err := cert.validFor(dnsNames[len(dnsNames)-2], time.Now().Add(certRenewBefore))
+		if err == nil {
+			log.Info("Serving the certificate of the secret", "secret", options.SecretName)
+			return cert, nil
+		}
+		log.Info("Replacing the certificate of the secret", "secret", options.SecretName, "reason", err.Error())
+	}
+
+	cert, err := generateCert(dnsNames, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("unable to generate the webhook certificate: %v", err)
+	}
+	secret.Name = options.SecretName
+	secret.Namespace = options.Namespace
+	secret.Data = map[string][]byte{secretCAKey: cert.ca, secretCertKey: cert.cert, secretKeyKey: cert.key}
+	if exists {
+		err = c.Update(context.TODO(), secret)
+	} else {
+		secret.Type = corev1.SecretTypeTLS
+		err = c.Create(context.TODO(), secret)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to store the webhook certificate in secret %s: %v", options.SecretName, err)
+	}
+	return cert, nil
+}
+
+// serviceDNSNames are the names the API server may call the Service with, the .svc one is used by the API server
+func serviceDNSNames(service, namespace string) []string {
+	return []string{
+		service,
+		fmt.Sprintf("%s.%s", service, namespace),
+		fmt.Sprintf("%s.%s.svc", service, namespace),
+		fmt.Sprintf("%s.%s.svc.cluster.local", service, namespace),
+	}
+}
+
+// validFor checks the certificate is signed by its CA, matches its key and is valid for dnsName until the given time
+func (c *certificate) validFor(dnsName string, until time.Time) error {
+	if len(c.ca) == 0 || len(c.cert) == 0 || len(c.key) == 0 {
+		return fmt.Errorf("the secret must hold %s, %s and %s", secretCAKey, secretCertKey, secretKeyKey)
+	}
+	if _, err := tls.X509KeyPair(c.cert, c.key); err != nil {
+		return err
+	}
+	block, _ := pem.Decode(c.cert)
+	if block == nil {
+		return fmt.Errorf("%s is not PEM encoded", secretCertKey)
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return err
+	}
+	roots := x509.NewCertPool()
+	if !roots.AppendCertsFromPEM(c.ca) {
+		return fmt.Errorf("%s holds no PEM encoded certificate", secretCAKey)
+	}
+	_, err = cert.Verify(x509.VerifyOptions{
+		DNSName:     dnsName,
+		Roots:       roots,
+		CurrentTime: until,
+		KeyUsages:   []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	})
+	return err
+}
+
+// generateCert generates a self-signed CA and a serving certificate for dnsNames signed by it
+func generateCert(dnsNames []string, now time.Time) (*certificate, error) {
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: fmt.Sprintf("%s-ca", dnsNames[0])},
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              now.Add(certValidity),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, &caKey.PublicKey, caKey)
+	if err != nil {
+		return nil, err
+	}
+	caCert, err := x509.ParseCertificate(caDER)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: dnsNames[len(dnsNames)-2]},
+		DNSNames:     dnsNames,
+		NotBefore:    now.Add(-time.Hour),
+		NotAfter:     now.Add(certValidity),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, caCert, &key.PublicKey, caKey)
+	if err != nil {
+		return nil, err
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return nil, err
+	}
+	return &certificate{
+		ca:   pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: caDER}),
+		cert: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		key:  pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}),
+	}, nil
+}
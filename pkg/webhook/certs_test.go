@@ -0,0 +1,54 @@
+package webhook
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGeneratedCertIsValidForTheService(t *testing.T) {
+	now := time.Now()
+	dnsNames := serviceDNSNames("descheduler-operator-webhook", "kube-system")
+	cert, err := generateCert(dnsNames, now)
+	if err != nil {
+		t.Fatalf("generateCert: %v", err)
+	}
+	for _, dnsName := range dnsNames {
+		if err := cert.validFor(dnsName, now.Add(certRenewBefore)); err != nil {
+			t.Errorf("certificate not valid for %s: %v", dnsName, err)
+		}
+	}
+	if err := cert.validFor("descheduler-operator-webhook.default.svc", now); err == nil {
+		t.Error("certificate valid for the service of another namespace")
+	}
+	if err := cert.validFor(dnsNames[2], now.Add(certValidity)); err == nil {
+		t.Error("certificate valid after its expiry")
+	}
+}
+
+func TestValidForRejectsIncompleteOrMismatchedSecrets(t *testing.T) {
+	now := time.Now()
+	dnsNames := serviceDNSNames("descheduler-operator-webhook", "kube-system")
+	cert, err := generateCert(dnsNames, now)
+	if err != nil {
+		t.Fatalf("generateCert: %v", err)
+	}
+	other, err := generateCert(dnsNames, now)
+	if err != nil {
+		t.Fatalf("generateCert: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		cert *certificate
+	}{
+		{"no CA", &certificate{cert: cert.cert, key: cert.key}},
+		{"no key", &certificate{ca: cert.ca, cert: cert.cert}},
+		{"key of another certificate", &certificate{ca: cert.ca, cert: cert.cert, key: other.key}},
+		{"signed by another CA", &certificate{ca: other.ca, cert: cert.cert, key: cert.key}},
+	}
+	for _, test := range tests {
+		if err := test.cert.validFor(dnsNames[2], now); err == nil {
+			t.Errorf("%s: expected an error", test.name)
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package descheduler
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	deschedulerv1alpha1 "github.com/skckadiyala/descheduler-operator/pkg/apis/descheduler/v1alpha1"
+	deschedulerv1beta1 "github.com/skckadiyala/descheduler-operator/pkg/apis/descheduler/v1beta1"
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// admitFunc reviews an admission request and returns the response sent back to the API server
+type admitFunc func(*admissionv1beta1.AdmissionRequest) *admissionv1beta1.AdmissionResponse
+
+// serveAdmission decodes the AdmissionReview in r, runs admit on it and writes the review back to w
+func serveAdmission(w http.ResponseWriter, r *http.Request, admit admitFunc) {
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	review := &admissionv1beta1.AdmissionReview{}
+	if err := json.Unmarshal(body, review); err != nil || review.Request == nil {
+		http.Error(w, fmt.Sprintf("unable to decode admission review %v", err), http.StatusBadRequest)
+		return
+	}
+
+	review.Response = admit(review.Request)
+	review.Response.UID = review.Request.UID
+	review.Request = nil
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(review); err != nil {
+		logwh.Error(err, "Failed to write admission response")
+	}
+}
+
+// decodeDescheduler decodes the Descheduler in raw, sent in the given version, into its v1beta1 form
+func decodeDescheduler(raw []byte, version string) (*deschedulerv1beta1.Descheduler, error) {
+	descheduler := &deschedulerv1beta1.Descheduler{}
+	switch version {
+	case deschedulerv1beta1.SchemeGroupVersion.Version:
+		if err := json.Unmarshal(raw, descheduler); err != nil {
+			return nil, err
+		}
+	case deschedulerv1alpha1.SchemeGroupVersion.Version:
+		src := &deschedulerv1alpha1.Descheduler{}
+		if err := json.Unmarshal(raw, src); err != nil {
+			return nil, err
+		}
+		if err := src.ConvertTo(descheduler); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unsupported descheduler version %s", version)
+	}
+	return descheduler, nil
+}
+
+// denied returns a response rejecting the request with err as message
+func denied(err error) *admissionv1beta1.AdmissionResponse {
+	return &admissionv1beta1.AdmissionResponse{
+		Allowed: false,
+		Result: &metav1.Status{
+			Status:  metav1.StatusFailure,
+			Reason:  metav1.StatusReasonInvalid,
+			Code:    http.StatusUnprocessableEntity,
+			Message: err.Error(),
+		},
+	}
+}
@@ -0,0 +1,93 @@
+package descheduler
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	deschedulerv1alpha1 "github.com/skckadiyala/descheduler-operator/pkg/apis/descheduler/v1alpha1"
+	deschedulerv1beta1 "github.com/skckadiyala/descheduler-operator/pkg/apis/descheduler/v1beta1"
+	apiextensionsv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// conversionHandler converts Descheduler objects between the served versions for the API server.
+// v1beta1 is the storage version, every conversion goes from or to it.
+type conversionHandler struct{}
+
+func (h *conversionHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	review := &apiextensionsv1beta1.ConversionReview{}
+	if err := json.Unmarshal(body, review); err != nil || review.Request == nil {
+		http.Error(w, fmt.Sprintf("unable to decode conversion review %v", err), http.StatusBadRequest)
+		return
+	}
+
+	review.Response = convertObjects(review.Request)
+	review.Request = nil
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(review); err != nil {
+		logwh.Error(err, "Failed to write conversion response")
+	}
+}
+
+func convertObjects(request *apiextensionsv1beta1.ConversionRequest) *apiextensionsv1beta1.ConversionResponse {
+	response := &apiextensionsv1beta1.ConversionResponse{UID: request.UID}
+	for _, object := range request.Objects {
+		converted, err := convertDescheduler(object.Raw, request.DesiredAPIVersion)
+		if err != nil {
+			logwh.Info("Failed to convert descheduler", "desiredAPIVersion", request.DesiredAPIVersion, "error", err.Error())
+			response.ConvertedObjects = nil
+			response.Result = metav1.Status{Status: metav1.StatusFailure, Message: err.Error()}
+			return response
+		}
+		response.ConvertedObjects = append(response.ConvertedObjects, runtime.RawExtension{Raw: converted})
+	}
+	response.Result = metav1.Status{Status: metav1.StatusSuccess}
+	return response
+}
+
+// convertDescheduler converts a serialized Descheduler to desiredAPIVersion
+func convertDescheduler(raw []byte, desiredAPIVersion string) ([]byte, error) {
+	typeMeta := metav1.TypeMeta{}
+	if err := json.Unmarshal(raw, &typeMeta); err != nil {
+		return nil, err
+	}
+
+	alphaVersion := deschedulerv1alpha1.SchemeGroupVersion.String()
+	betaVersion := deschedulerv1beta1.SchemeGroupVersion.String()
+	switch {
+	case typeMeta.APIVersion == desiredAPIVersion:
+		return raw, nil
+	case typeMeta.APIVersion == alphaVersion && desiredAPIVersion == betaVersion:
+		src := &deschedulerv1alpha1.Descheduler{}
+		if err := json.Unmarshal(raw, src); err != nil {
+			return nil, err
+		}
+		dst := &deschedulerv1beta1.Descheduler{}
+		if err := src.ConvertTo(dst); err != nil {
+			return nil, fmt.Errorf("unable to convert descheduler %s/%s to %s: %v", src.Namespace, src.Name, betaVersion, err)
+		}
+		dst.TypeMeta = metav1.TypeMeta{APIVersion: betaVersion, Kind: typeMeta.Kind}
+		return json.Marshal(dst)
+	case typeMeta.APIVersion == betaVersion && desiredAPIVersion == alphaVersion:
+		src := &deschedulerv1beta1.Descheduler{}
+		if err := json.Unmarshal(raw, src); err != nil {
+			return nil, err
+		}
+		dst := &deschedulerv1alpha1.Descheduler{}
+		if err := dst.ConvertFrom(src); err != nil {
+			return nil, fmt.Errorf("unable to convert descheduler %s/%s to %s: %v", src.Namespace, src.Name, alphaVersion, err)
+		}
+		dst.TypeMeta = metav1.TypeMeta{APIVersion: alphaVersion, Kind: typeMeta.Kind}
+		return json.Marshal(dst)
+	}
+	return nil, fmt.Errorf("unsupported conversion from %s to %s", typeMeta.APIVersion, desiredAPIVersion)
+}
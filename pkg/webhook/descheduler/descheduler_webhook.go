@@ -0,0 +1,25 @@
+package descheduler
+
+import (
+	"net/http"
+
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	logf "sigs.k8s.io/controller-runtime/pkg/runtime/log"
+)
+
+var logwh = logf.Log.WithName("webhook_descheduler")
+
+// Paths the Descheduler webhooks are served on
+const (
+	ConversionPath = "/convert"
+	ValidatingPath = "/validate-descheduler"
+	MutatingPath   = "/mutate-descheduler"
+)
+
+// Add registers the Descheduler webhooks with mux
+func Add(mgr manager.Manager, mux *http.ServeMux) error {
+	mux.Handle(ConversionPath, &conversionHandler{})
+	mux.Handle(ValidatingPath, &validatingHandler{})
+	mux.Handle(MutatingPath, &mutatingHandler{})
+	return nil
+}
@@ -0,0 +1,58 @@
+package descheduler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/appscode/jsonpatch"
+	deschedulerv1alpha1 "github.com/skckadiyala/descheduler-operator/pkg/apis/descheduler/v1alpha1"
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// mutatingHandler persists the defaults of unset fields into Descheduler objects, so that the stored
+// object shows exactly what will run and new operator defaults don't change existing Deschedulers.
+type mutatingHandler struct{}
+
+func (h *mutatingHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	serveAdmission(w, r, defaultDescheduler)
+}
+
+func defaultDescheduler(request *admissionv1beta1.AdmissionRequest) *admissionv1beta1.AdmissionResponse {
+	descheduler, err := decodeDescheduler(request.Object.Raw, request.Kind.Version)
+	if err != nil {
+		return denied(err)
+	}
+	descheduler.Default()
+
+	// The patch applies to the version of the request, v1alpha1 keeps the defaults it can't express in the v1beta1
+	// spec annotation, which is restored when the object is converted to the storage version
+	var defaulted interface{} = descheduler
+	if request.Kind.Version == deschedulerv1alpha1.SchemeGroupVersion.Version {
+		converted := &deschedulerv1alpha1.Descheduler{}
+		if err := converted.ConvertFrom(descheduler); err != nil {
+			return denied(err)
+		}
+		converted.TypeMeta = metav1.TypeMeta{APIVersion: deschedulerv1alpha1.SchemeGroupVersion.String(), Kind: request.Kind.Kind}
+		defaulted = converted
+	}
+	defaultedJSON, err := json.Marshal(defaulted)
+	if err != nil {
+		return denied(err)
+	}
+	patch, err := jsonpatch.CreatePatch(request.Object.Raw, defaultedJSON)
+	if err != nil {
+		return denied(err)
+	}
+	patchBytes, err := json.Marshal(patch)
+	if err != nil {
+		return denied(err)
+	}
+
+	patchType := admissionv1beta1.PatchTypeJSONPatch
+	return &admissionv1beta1.AdmissionResponse{
+		Allowed:   true,
+		Patch:     patchBytes,
+		PatchType: &patchType,
+	}
+}
@@ -0,0 +1,63 @@
+package descheduler
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/appscode/jsonpatch"
+	deschedulerv1alpha1 "github.com/skckadiyala/descheduler-operator/pkg/apis/descheduler/v1alpha1"
+	deschedulerv1beta1 "github.com/skckadiyala/descheduler-operator/pkg/apis/descheduler/v1beta1"
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestDefaultDescheduler(t *testing.T) {
+	tests := []struct {
+		version string
+		raw     string
+		// patched are the paths the patch must add or replace
+		patched []string
+	}{
+		{
+			version: deschedulerv1beta1.SchemeGroupVersion.Version,
+			raw:     `{"apiVersion":"descheduler.axway.com/v1beta1","kind":"Descheduler","metadata":{"name":"descheduler"},"spec":{}}`,
+			patched: []string{"/spec/image", "/spec/mode", "/spec/schedule"},
+		},
+		{
+			// The defaults v1alpha1 can't express are kept in the v1beta1 spec annotation
+			version: deschedulerv1alpha1.SchemeGroupVersion.Version,
+			raw:     `{"apiVersion":"descheduler.axway.com/v1alpha1","kind":"Descheduler","metadata":{"name":"descheduler"},"spec":{"flags":[]}}`,
+			patched: []string{"/metadata/annotations", "/spec/image", "/spec/schedule"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.version, func(t *testing.T) {
+			response := defaultDescheduler(&admissionv1beta1.AdmissionRequest{
+				Kind:   metav1.GroupVersionKind{Group: deschedulerv1beta1.SchemeGroupVersion.Group, Version: test.version, Kind: "Descheduler"},
+				Object: runtime.RawExtension{Raw: []byte(test.raw)},
+			})
+			if !response.Allowed {
+				t.Fatalf("expected the request to be allowed, got %v", response.Result)
+			}
+			var patch []jsonpatch.Operation
+			if err := json.Unmarshal(response.Patch, &patch); err != nil {
+				t.Fatalf("invalid patch %v", err)
+			}
+			operations := map[string]string{}
+			for _, operation := range patch {
+				operations[operation.Path] = operation.Operation
+			}
+			for _, path := range test.patched {
+				if operation := operations[path]; operation != "add" && operation != "replace" {
+					t.Errorf("expected %s to be patched, got %s", path, response.Patch)
+				}
+			}
+			for _, path := range []string{"/apiVersion", "/kind"} {
+				if _, ok := operations[path]; ok {
+					t.Errorf("expected %s to be kept, got %s", path, response.Patch)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package descheduler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	deschedulerv1alpha1 "github.com/skckadiyala/descheduler-operator/pkg/apis/descheduler/v1alpha1"
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+)
+
+// validatingHandler rejects invalid Descheduler objects when they are created or updated,
+// so that a bad CR fails at apply time rather than in the operator log.
+type validatingHandler struct{}
+
+func (h *validatingHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	serveAdmission(w, r, validateDescheduler)
+}
+
+func validateDescheduler(request *admissionv1beta1.AdmissionRequest) *admissionv1beta1.AdmissionResponse {
+	descheduler, err := decodeDescheduler(request.Object.Raw, request.Kind.Version)
+	if err != nil {
+		return denied(err)
+	}
+	if request.Kind.Version == deschedulerv1alpha1.SchemeGroupVersion.Version {
+		// Conversion drops the strategies and params v1beta1 can't express, they are rejected here
+		src := &deschedulerv1alpha1.Descheduler{}
+		if err := json.Unmarshal(request.Object.Raw, src); err != nil {
+			return denied(err)
+		}
+		err = deschedulerv1alpha1.ValidateStrategies(src.Spec.Strategies)
+	}
+	if err == nil {
+		err = descheduler.Validate()
+	}
+	if err != nil {
+		logwh.Info("Rejecting descheduler", "namespace", request.Namespace, "name", request.Name, "error", err.Error())
+		return denied(err)
+	}
+	return &admissionv1beta1.AdmissionResponse{Allowed: true}
+}
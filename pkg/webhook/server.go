@@ -0,0 +1,77 @@
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	logf "sigs.k8s.io/controller-runtime/pkg/runtime/log"
+)
+
+var log = logf.Log.WithName("webhook_server")
+
+// blank assignment to verify that Server implements manager.Runnable
+var _ manager.Runnable = &Server{}
+
+// Server serves the admission and conversion webhooks of the operator over TLS
+type Server struct {
+	// Port the server listens on
+	Port int32
+	// CertDir is the directory holding the tls.crt and tls.key the server is serving with.
+	// It is written by the Server when Certs is set, otherwise it is usually mounted from a secret.
+	CertDir string
+	// Certs provisions the serving certificate and the caBundle of the webhooks, nil to serve the ones of CertDir
+	Certs *CertOptions
+
+	mux    *http.ServeMux
+	client client.Client
+}
+
+// Start serves the registered webhooks until stop is closed
+func (s *Server) Start(stop <-chan struct{}) error {
+	if s.Certs != nil {
+		ca, err := provisionCerts(s.client, s.Certs, s.CertDir)
+		if err != nil {
+			return fmt.Errorf("unable to provision the webhook certificate: %v", err)
+		}
+		if err := setCABundles(s.client, s.Certs, ca); err != nil {
+			return err
+		}
+		go func() {
+			ticker := time.NewTicker(caBundleResyncPeriod)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-stop:
+					return
+				case <-ticker.C:
+					if err := setCABundles(s.client, s.Certs, ca); err != nil {
+						log.Error(err, "Failed to set the caBundle of the webhooks")
+					}
+				}
+			}
+		}()
+	}
+
+	srv := &http.Server{
+		Addr:    fmt.Sprintf(":%d", s.Port),
+		Handler: s.mux,
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		log.Info("Starting the webhook server", "port", s.Port, "certDir", s.CertDir)
+		errCh <- srv.ListenAndServeTLS(filepath.Join(s.CertDir, "tls.crt"), filepath.Join(s.CertDir, "tls.key"))
+	}()
+
+	select {
+	case <-stop:
+		return srv.Shutdown(context.TODO())
+	case err := <-errCh:
+		return err
+	}
+}
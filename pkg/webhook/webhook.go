@@ -0,0 +1,32 @@
+package webhook
+
+import (
+	"net/http"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+// AddToServerFuncs is a list of functions to register all Webhooks with the Server
+var AddToServerFuncs []func(manager.Manager, *http.ServeMux) error
+
+// AddToManager registers all Webhooks with a Server listening on port and adds the Server to the Manager.
+// The Server is started together with the Manager, it provisions its certificate first unless certs is nil.
+func AddToManager(m manager.Manager, port int32, certDir string, certs *CertOptions) error {
+	s := &Server{Port: port, CertDir: certDir, Certs: certs, mux: http.NewServeMux()}
+	if certs != nil {
+		// The certificate is provisioned before the cache of the manager is synced, and the configurations it
+		// patches are cluster scoped, so they are read from the API server
+		c, err := client.New(m.GetConfig(), client.Options{Scheme: m.GetScheme(), Mapper: m.GetRESTMapper()})
+		if err != nil {
+			return err
+		}
+		s.client = c
+	}
+	for _, f := range AddToServerFuncs {
+		if err := f(m, s.mux); err != nil {
+			return err
+		}
+	}
+	return m.Add(s)
+}